@@ -0,0 +1,40 @@
+package env
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// DataSource returns the database/sql driver name and data source name to connect with.
+// When DSN is empty, a MySQL data source is built from the connection fields.
+func (e *Env) DataSource() (string, string, error) {
+	if len(e.DSN) == 0 {
+		return "mysql", fmt.Sprintf("%s:%s@(%s:%s)/%s", e.Username, e.Password, e.Host, e.Port, e.Database), nil
+	}
+
+	scheme := strings.SplitN(e.DSN, "://", 2)
+	if len(scheme) != 2 {
+		return "", "", fmt.Errorf("dsn %q is missing a scheme", e.DSN)
+	}
+
+	switch strings.ToLower(scheme[0]) {
+	case "mysql":
+		u, err := url.Parse(e.DSN)
+		if err != nil {
+			return "", "", err
+		}
+		password, _ := u.User.Password()
+		dataSource := fmt.Sprintf("%s:%s@tcp(%s)%s", u.User.Username(), password, u.Host, u.Path)
+		if len(u.RawQuery) > 0 {
+			dataSource = fmt.Sprintf("%s?%s", dataSource, u.RawQuery)
+		}
+		return "mysql", dataSource, nil
+	case "postgres", "postgresql":
+		return "postgres", e.DSN, nil
+	case "sqlite", "sqlite3":
+		return "sqlite3", scheme[1], nil
+	default:
+		return "", "", fmt.Errorf("%s driver is not supported", scheme[0])
+	}
+}
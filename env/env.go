@@ -8,12 +8,27 @@ import (
 )
 
 // Env Environment info
+// DSN is a database url (mysql://, postgres:// or sqlite://), which takes
+// precedence over the other connection fields.
+// Types overrides the default SQL to GraphQL type mappings, in order.
+// Connections adds Relay connection queries paginated by cursor.
 type Env struct {
-	Host     string `yaml:"host"`
-	Port     string `yaml:"port"`
-	Username string `yaml:"username"`
-	Password string `yaml:"password"`
-	Database string `yaml:"database"`
+	DSN         string        `yaml:"dsn"`
+	Host        string        `yaml:"host"`
+	Port        string        `yaml:"port"`
+	Username    string        `yaml:"username"`
+	Password    string        `yaml:"password"`
+	Database    string        `yaml:"database"`
+	Types       []TypeMapping `yaml:"types"`
+	Connections bool          `yaml:"connections"`
+}
+
+// TypeMapping maps SQL types matching the SQL regular expression, or the Column
+// named either "table.column" or "column", to a GraphQL scalar
+type TypeMapping struct {
+	SQL     string `yaml:"sql"`
+	Column  string `yaml:"column"`
+	GraphQL string `yaml:"graphql"`
 }
 
 // ReadEnv read environment info from env.yaml
@@ -10,6 +10,8 @@ import (
 
 	_ "github.com/go-sql-driver/mysql"
 	"github.com/graphql-go/handler"
+	_ "github.com/lib/pq"
+	_ "github.com/mattn/go-sqlite3"
 	"github.com/suppayami/goql/env"
 	"github.com/suppayami/goql/resolver"
 	"github.com/suppayami/goql/schema"
@@ -20,18 +22,46 @@ func main() {
 
 	e.ReadEnv()
 
-	// TODO: Auto detect database server (mysql|postgres|mongodb?)
-	db, err := sql.Open("mysql", fmt.Sprintf("%s:%s@(%s:%s)/%s", e.Username, e.Password, e.Host, e.Port, e.Database))
+	driver, dataSource, err := e.DataSource()
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	db, err := sql.Open(driver, dataSource)
 
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer db.Close()
 
-	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("mysql"))
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder(driver))
 	if err != nil {
 		log.Fatal(err)
 	}
+	typeMappings := []schema.SQLTypeMapping{}
+	columnTypes := make(map[string]schema.GraphqlType)
+	for _, t := range e.Types {
+		if len(t.Column) > 0 {
+			columnTypes[t.Column], err = schema.ScalarType(t.GraphQL)
+			if err != nil {
+				log.Fatal(err)
+			}
+			continue
+		}
+		mapping, err := schema.NewSQLTypeMapping(t.SQL, t.GraphQL)
+		if err != nil {
+			log.Fatal(err)
+		}
+		typeMappings = append(typeMappings, mapping)
+	}
+	sqlSchema.TypeMapper = schema.ColumnTypeMapper{
+		Columns: columnTypes,
+		Fallback: schema.TableTypeMapper{
+			Mappings: typeMappings,
+			Fallback: sqlSchema.TypeMapper,
+		},
+	}
+	sqlSchema.Connections = e.Connections
 	graphqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
 	if err != nil {
 		log.Fatal(err)
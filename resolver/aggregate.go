@@ -0,0 +1,211 @@
+package resolver
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/suppayami/goql/schema"
+)
+
+// aggregateColumn is an aggregate selected by an aggregate query, the field of result
+// it is read into.
+type aggregateColumn struct {
+	result  string
+	field   string
+	gqlType schema.GraphqlType
+}
+
+// getAggregateTable returns the table aggregated by the query field named fieldName,
+// or nil if the field is not an aggregate.
+func getAggregateTable(sqlSchema schema.SQLSchemaStruct, fieldName string) *schema.SQLTableStruct {
+	for _, sqlTable := range sqlSchema.Tables {
+		if schema.AggregateFieldName(sqlTable.Name) == fieldName {
+			return sqlTable
+		}
+	}
+	return nil
+}
+
+// makeAggregateReader reads aggregates of rows of table, grouped by the groupBy argument
+// if any. aggregateTypes holds the fields aggregated for each table, only those in
+// selection are read, by aggregate then field name.
+func makeAggregateReader(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	aggregateTypes []schema.GraphqlObjectType,
+) func(map[string]interface{}, map[string]map[string]bool) ([]map[string]interface{}, error) {
+	numbers := getAggregateFields(aggregateTypes, schema.SQLToGraphqlAggregateNumbersName(table.Name))
+	values := getAggregateFields(aggregateTypes, schema.SQLToGraphqlAggregateValuesName(table.Name))
+	return func(args map[string]interface{}, selection map[string]map[string]bool) ([]map[string]interface{}, error) {
+		sqlTxt, sqlArgs, aggregates, err := aggregateQuery(d, table, numbers, values, args, selection)
+		if err != nil {
+			return nil, err
+		}
+		aliasTypes := make(map[string]schema.GraphqlType)
+		for i, aggregate := range aggregates {
+			aliasTypes[fmt.Sprintf("a%d", i)] = aggregate.gqlType
+		}
+		rows, err := queryRows(db, sqlTxt, sqlArgs, aliasTypes)
+		if err != nil {
+			return nil, err
+		}
+		results := make([]map[string]interface{}, 0, len(rows))
+		for _, row := range rows {
+			result := make(map[string]interface{})
+			for _, function := range []string{schema.AggregateSum, schema.AggregateAvg, schema.AggregateMin, schema.AggregateMax} {
+				if _, ok := selection[function]; ok {
+					result[function] = make(map[string]interface{})
+				}
+			}
+			for i, aggregate := range aggregates {
+				value := row[fmt.Sprintf("a%d", i)]
+				if len(aggregate.field) == 0 {
+					result[aggregate.result] = value
+					continue
+				}
+				fields, ok := result[aggregate.result].(map[string]interface{})
+				if !ok {
+					fields = make(map[string]interface{})
+					result[aggregate.result] = fields
+				}
+				fields[aggregate.field] = value
+			}
+			results = append(results, result)
+		}
+		return results, nil
+	}
+}
+
+// aggregateQuery returns the statement reading the aggregates of table in selection
+// with its values, and the aggregate read by each column, aliased a0, a1... in order.
+// The count is always read so the statement selects something.
+func aggregateQuery(
+	d dialect,
+	table *schema.SQLTableStruct,
+	numbers []schema.GraphqlField,
+	values []schema.GraphqlField,
+	args map[string]interface{},
+	selection map[string]map[string]bool,
+) (string, []interface{}, []aggregateColumn, error) {
+	whereStatement, sqlArgs, err := whereConditions(d, table, args, make([]interface{}, 0))
+	if err != nil {
+		return "", nil, nil, err
+	}
+	aggregates := []aggregateColumn{
+		aggregateColumn{result: schema.AggregateCount, gqlType: schema.ScalarInt},
+	}
+	selected := []string{"COUNT(*)"}
+	for _, function := range []string{schema.AggregateSum, schema.AggregateAvg} {
+		for _, field := range numbers {
+			if !selection[function][field.Name] {
+				continue
+			}
+			aggregates = append(aggregates, aggregateColumn{result: function, field: field.Name, gqlType: field.Type})
+			selected = append(selected, d.aggregate(function, field.Type, aggregateIdentifier(d, table, field.Name)))
+		}
+	}
+	for _, function := range []string{schema.AggregateMin, schema.AggregateMax} {
+		for _, field := range values {
+			if !selection[function][field.Name] {
+				continue
+			}
+			aggregates = append(aggregates, aggregateColumn{result: function, field: field.Name, gqlType: field.Type})
+			selected = append(selected, d.aggregate(function, field.Type, aggregateIdentifier(d, table, field.Name)))
+		}
+	}
+	groupBy := make([]string, 0)
+	columns, _ := args[schema.GroupByArgumentName].([]interface{})
+	for _, column := range columns {
+		column, _ := column.(string)
+		fieldName := schema.SQLToGraphqlFieldName(column)
+		for _, field := range values {
+			if field.Name != fieldName {
+				continue
+			}
+			aggregates = append(aggregates, aggregateColumn{result: schema.AggregateGroup, field: field.Name, gqlType: field.Type})
+			selected = append(selected, d.quoteIdentifier(column))
+			groupBy = append(groupBy, d.quoteIdentifier(column))
+		}
+	}
+	aliases := make([]string, 0, len(selected))
+	for i := range aggregates {
+		aliases = append(aliases, fmt.Sprintf("%s AS a%d", selected[i], i))
+	}
+	sqlTxt := fmt.Sprintf("SELECT %s FROM %s", strings.Join(aliases, ", "), d.quoteTable(table))
+	if len(whereStatement) > 0 {
+		sqlTxt = fmt.Sprintf("%s WHERE %s", sqlTxt, strings.Join(whereStatement, " AND "))
+	}
+	if len(groupBy) > 0 {
+		sqlTxt = fmt.Sprintf("%s GROUP BY %s ORDER BY %s", sqlTxt, strings.Join(groupBy, ", "), strings.Join(groupBy, ", "))
+	}
+	return sqlTxt, sqlArgs, aggregates, nil
+}
+
+// aggregateSelection returns the fields selected under each field of an aggregate query,
+// following fragments.
+func aggregateSelection(info graphql.ResolveInfo) map[string]map[string]bool {
+	selectionSets := make([]*ast.SelectionSet, 0, len(info.FieldASTs))
+	for _, field := range info.FieldASTs {
+		selectionSets = append(selectionSets, field.SelectionSet)
+	}
+	selection := make(map[string]map[string]bool)
+	for name, fieldSets := range selectedFields(info.Fragments, selectionSets) {
+		selection[name] = make(map[string]bool)
+		for fieldName := range selectedFields(info.Fragments, fieldSets) {
+			selection[name][fieldName] = true
+		}
+	}
+	return selection
+}
+
+// selectedFields returns the selection sets of the fields selected in selectionSets,
+// by field name.
+func selectedFields(fragments map[string]ast.Definition, selectionSets []*ast.SelectionSet) map[string][]*ast.SelectionSet {
+	fields := make(map[string][]*ast.SelectionSet)
+	var collect func(*ast.SelectionSet)
+	collect = func(selectionSet *ast.SelectionSet) {
+		if selectionSet == nil {
+			return
+		}
+		for _, selection := range selectionSet.Selections {
+			switch s := selection.(type) {
+			case *ast.Field:
+				fields[s.Name.Value] = append(fields[s.Name.Value], s.SelectionSet)
+			case *ast.InlineFragment:
+				collect(s.SelectionSet)
+			case *ast.FragmentSpread:
+				if fragment, ok := fragments[s.Name.Value].(*ast.FragmentDefinition); ok {
+					collect(fragment.SelectionSet)
+				}
+			}
+		}
+	}
+	for _, selectionSet := range selectionSets {
+		collect(selectionSet)
+	}
+	return fields
+}
+
+// getAggregateFields returns the fields of the aggregate type named name, if any.
+func getAggregateFields(aggregateTypes []schema.GraphqlObjectType, name string) []schema.GraphqlField {
+	for _, gql := range aggregateTypes {
+		if gql.Name == name {
+			return gql.Fields
+		}
+	}
+	return nil
+}
+
+// aggregateIdentifier returns the quoted column of table named fieldName in graphql.
+func aggregateIdentifier(d dialect, table *schema.SQLTableStruct, fieldName string) string {
+	for _, field := range table.Fields {
+		if schema.SQLToGraphqlFieldName(field.Field) == fieldName {
+			return d.quoteIdentifier(field.Field)
+		}
+	}
+	return d.quoteIdentifier(schema.GraphqlToSQLFieldName(fieldName))
+}
@@ -0,0 +1,60 @@
+package resolver
+
+import (
+	"testing"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func TestAggregateQuery(t *testing.T) {
+	table := &schema.SQLTableStruct{
+		Name: "game",
+		Fields: []*schema.SQLFieldStruct{
+			&schema.SQLFieldStruct{Field: "game_id", Type: "integer", IsPrimaryKey: true},
+			&schema.SQLFieldStruct{Field: "price", Type: "numeric"},
+			&schema.SQLFieldStruct{Field: "released", Type: "boolean"},
+		},
+	}
+	numbers := []schema.GraphqlField{
+		schema.GraphqlField{Name: "price", Type: schema.ScalarDecimal},
+	}
+	values := []schema.GraphqlField{
+		schema.GraphqlField{Name: "price", Type: schema.ScalarDecimal},
+		schema.GraphqlField{Name: "released", Type: schema.ScalarBoolean},
+	}
+	selection := map[string]map[string]bool{
+		schema.AggregateSum: map[string]bool{"price": true},
+		schema.AggregateMin: map[string]bool{"released": true},
+		schema.AggregateMax: map[string]bool{"released": true},
+	}
+
+	sqlTxt, _, aggregates, err := aggregateQuery(getDialect("postgres"), table, numbers, values, map[string]interface{}{}, selection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := `SELECT COUNT(*) AS a0, SUM("price") AS a1, bool_and("released") AS a2, bool_or("released") AS a3 FROM "game"`
+	if sqlTxt != expected {
+		t.Fatalf("Expected: \n%s\nGot:\n%s\n", expected, sqlTxt)
+	}
+	if len(aggregates) != 4 {
+		t.Fatalf("Expected 4 aggregates, got %d", len(aggregates))
+	}
+
+	sqlTxt, _, _, err = aggregateQuery(getDialect("mysql"), table, numbers, values, map[string]interface{}{}, selection)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "SELECT COUNT(*) AS a0, SUM(`price`) AS a1, MIN(`released`) AS a2, MAX(`released`) AS a3 FROM `game`"
+	if sqlTxt != expected {
+		t.Fatalf("Expected: \n%s\nGot:\n%s\n", expected, sqlTxt)
+	}
+
+	sqlTxt, _, _, err = aggregateQuery(getDialect("postgres"), table, numbers, values, map[string]interface{}{}, map[string]map[string]bool{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = `SELECT COUNT(*) AS a0 FROM "game"`
+	if sqlTxt != expected {
+		t.Fatalf("Expected: \n%s\nGot:\n%s\n", expected, sqlTxt)
+	}
+}
@@ -0,0 +1,288 @@
+package resolver
+
+import (
+	"bytes"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/graphql-go/graphql"
+	"github.com/suppayami/goql/schema"
+)
+
+// defaultPageSize is the number of rows of a page when neither first nor last is given.
+const defaultPageSize = 10
+
+// getConnectionTable returns the table paginated by the connection field named fieldName,
+// or nil if the field is not a connection.
+func getConnectionTable(sqlSchema schema.SQLSchemaStruct, fieldName string) *schema.SQLTableStruct {
+	if !sqlSchema.Connections {
+		return nil
+	}
+	for _, sqlTable := range sqlSchema.Tables {
+		if len(sqlTable.PrimaryKeys) > 0 && schema.ConnectionFieldName(sqlTable.Name) == fieldName {
+			return sqlTable
+		}
+	}
+	return nil
+}
+
+// makeConnectionReader reads a page of rows of table with keyset pagination: rows are
+// ordered by the orderBy argument then the primary key, and after and before cursors
+// hold the values of these columns in the row the page starts or ends next to.
+func makeConnectionReader(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) func(map[string]interface{}) (map[string]interface{}, error) {
+	return func(args map[string]interface{}) (map[string]interface{}, error) {
+		first, hasFirst := args[schema.ConnectionFirst].(int)
+		last, hasLast := args[schema.ConnectionLast].(int)
+		if (hasFirst && first < 0) || (hasLast && last < 0) {
+			return nil, fmt.Errorf("first and last must not be negative")
+		}
+		if !hasFirst && !hasLast {
+			first, hasFirst = defaultPageSize, true
+		}
+		whereStatement, sqlArgs, err := whereConditions(d, table, args, make([]interface{}, 0))
+		if err != nil {
+			return nil, err
+		}
+		orders := orderColumns(table, args[schema.OrderByArgumentName])
+		after, hasAfter := args[schema.ConnectionAfter].(string)
+		if hasAfter {
+			values, err := decodeCursor(orders, columns, after)
+			if err != nil {
+				return nil, err
+			}
+			var condition string
+			condition, sqlArgs = keysetCondition(d, table, orders, values, false, sqlArgs)
+			whereStatement = append(whereStatement, condition)
+		}
+		before, hasBefore := args[schema.ConnectionBefore].(string)
+		if hasBefore {
+			values, err := decodeCursor(orders, columns, before)
+			if err != nil {
+				return nil, err
+			}
+			var condition string
+			condition, sqlArgs = keysetCondition(d, table, orders, values, true, sqlArgs)
+			whereStatement = append(whereStatement, condition)
+		}
+		// without first, the last rows are read backward
+		backward := hasLast && !hasFirst
+		sqlOrders := orders
+		if backward {
+			sqlOrders = reverseOrder(orders)
+		}
+		sqlTxt := fmt.Sprintf("SELECT %s FROM %s t", d.selectList(table, columns, "t"), d.quoteTable(table))
+		if len(whereStatement) > 0 {
+			sqlTxt = fmt.Sprintf("%s WHERE %s", sqlTxt, strings.Join(whereStatement, " AND "))
+		}
+		sqlTxt = fmt.Sprintf("%s%s", sqlTxt, orderByColumns(d, sqlOrders, "t"))
+		// one more row tells whether there is a next page
+		limit := first + 1
+		if backward {
+			limit = last + 1
+		}
+		sqlArgs = append(sqlArgs, limit)
+		sqlTxt = fmt.Sprintf("%s LIMIT %s", sqlTxt, d.placeholder(len(sqlArgs)))
+		rows, err := queryRows(db, sqlTxt, sqlArgs, columns)
+		if err != nil {
+			return nil, err
+		}
+		hasNextPage := hasBefore
+		hasPreviousPage := hasAfter
+		if backward {
+			if len(rows) > last {
+				hasPreviousPage = true
+				rows = rows[:last]
+			}
+			for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+				rows[i], rows[j] = rows[j], rows[i]
+			}
+		} else {
+			if len(rows) > first {
+				hasNextPage = true
+				rows = rows[:first]
+			}
+			if hasLast && len(rows) > last {
+				hasPreviousPage = true
+				rows = rows[len(rows)-last:]
+			}
+		}
+		return connectionResult(orders, columns, rows, hasPreviousPage, hasNextPage)
+	}
+}
+
+// connectionResult returns the edges of rows with their cursors and the page info.
+func connectionResult(
+	orders []orderColumn,
+	columns map[string]schema.GraphqlType,
+	rows []map[string]interface{},
+	hasPreviousPage bool,
+	hasNextPage bool,
+) (map[string]interface{}, error) {
+	edges := make([]map[string]interface{}, 0, len(rows))
+	for _, row := range rows {
+		cursor, err := encodeCursor(orders, columns, row)
+		if err != nil {
+			return nil, err
+		}
+		edges = append(edges, map[string]interface{}{
+			schema.EdgeNode:   row,
+			schema.EdgeCursor: cursor,
+		})
+	}
+	pageInfo := map[string]interface{}{
+		"hasNextPage":     hasNextPage,
+		"hasPreviousPage": hasPreviousPage,
+		"startCursor":     nil,
+		"endCursor":       nil,
+	}
+	if len(edges) > 0 {
+		pageInfo["startCursor"] = edges[0][schema.EdgeCursor]
+		pageInfo["endCursor"] = edges[len(edges)-1][schema.EdgeCursor]
+	}
+	return map[string]interface{}{
+		schema.ConnectionEdges:    edges,
+		schema.ConnectionPageInfo: pageInfo,
+	}, nil
+}
+
+// keysetCondition matches rows ordered after the row holding values in the order
+// columns, or before it. NULL is ordered as the dialect does, so the row may have been
+// deleted since the cursor was read.
+func keysetCondition(
+	d dialect,
+	table *schema.SQLTableStruct,
+	orders []orderColumn,
+	values map[string]interface{},
+	before bool,
+	args []interface{},
+) (string, []interface{}) {
+	column := func(order orderColumn) string {
+		return fmt.Sprintf("t.%s", d.quoteIdentifier(order.column))
+	}
+	conditions := make([]string, 0, len(orders))
+	for i, order := range orders {
+		value := d.timeValue(values[order.column])
+		nullable := true
+		if field := table.FindField(order.column); field != nil {
+			nullable = field.Null
+		}
+		// NULL is the greatest value unless the dialect orders it first
+		greater := order.desc == before
+		if value == nil && greater != d.nullsFirst {
+			// no value is ordered beyond NULL
+			continue
+		}
+		statements := make([]string, 0, i+1)
+		for _, equal := range orders[:i] {
+			if equalValue := d.timeValue(values[equal.column]); equalValue != nil {
+				args = append(args, equalValue)
+				statements = append(statements, fmt.Sprintf("%s = %s", column(equal), d.placeholder(len(args))))
+			} else {
+				statements = append(statements, fmt.Sprintf("%s IS NULL", column(equal)))
+			}
+		}
+		if value == nil {
+			statements = append(statements, fmt.Sprintf("%s IS NOT NULL", column(order)))
+		} else {
+			args = append(args, value)
+			operator := ">"
+			if !greater {
+				operator = "<"
+			}
+			statement := fmt.Sprintf("%s %s %s", column(order), operator, d.placeholder(len(args)))
+			if nullable && greater != d.nullsFirst {
+				statement = fmt.Sprintf("(%s OR %s IS NULL)", statement, column(order))
+			}
+			statements = append(statements, statement)
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s)", strings.Join(statements, " AND ")))
+	}
+	if len(conditions) == 0 {
+		return "1 = 0", args
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, " OR ")), args
+}
+
+func reverseOrder(orders []orderColumn) []orderColumn {
+	reversed := make([]orderColumn, 0, len(orders))
+	for _, order := range orders {
+		reversed = append(reversed, orderColumn{column: order.column, desc: !order.desc})
+	}
+	return reversed
+}
+
+// encodeCursor returns the opaque cursor of row, the values of its order columns as JSON.
+func encodeCursor(orders []orderColumn, columns map[string]schema.GraphqlType, row map[string]interface{}) (string, error) {
+	values := make(map[string]interface{})
+	for _, order := range orders {
+		fieldName := schema.SQLToGraphqlFieldName(order.column)
+		value := row[fieldName]
+		if scalar := cursorScalar(columns[fieldName]); scalar != nil {
+			value = scalar.Serialize(value)
+		}
+		values[order.column] = value
+	}
+	b, err := json.Marshal(values)
+	if err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(b), nil
+}
+
+// decodeCursor returns the values of the order columns held by a cursor, by column name.
+func decodeCursor(orders []orderColumn, columns map[string]schema.GraphqlType, cursor string) (map[string]interface{}, error) {
+	b, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	values := make(map[string]interface{})
+	decoder := json.NewDecoder(bytes.NewReader(b))
+	decoder.UseNumber()
+	if err := decoder.Decode(&values); err != nil {
+		return nil, fmt.Errorf("invalid cursor %q", cursor)
+	}
+	for _, order := range orders {
+		value, ok := values[order.column]
+		if !ok {
+			return nil, fmt.Errorf("invalid cursor %q", cursor)
+		}
+		if number, ok := value.(json.Number); ok {
+			if i, err := number.Int64(); err == nil {
+				value = i
+			} else if f, err := number.Float64(); err == nil {
+				value = f
+			}
+		}
+		if scalar := cursorScalar(columns[schema.SQLToGraphqlFieldName(order.column)]); scalar != nil && value != nil {
+			value = scalar.ParseValue(value)
+		}
+		values[order.column] = value
+	}
+	return values, nil
+}
+
+// cursorScalar returns the scalar values of gqlType are written in cursors with, so
+// they are read back as client arguments are, or nil when they are written as is.
+func cursorScalar(gqlType schema.GraphqlType) *graphql.Scalar {
+	switch gqlType {
+	case schema.ScalarDate:
+		return dateScalar
+	case schema.ScalarDateTime:
+		return dateTimeScalar
+	case schema.ScalarTime:
+		return timeScalar
+	case schema.ScalarDecimal:
+		return decimalScalar
+	case schema.ScalarBigInt:
+		return bigIntScalar
+	}
+	return nil
+}
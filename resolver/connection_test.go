@@ -0,0 +1,72 @@
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func TestKeysetCondition(t *testing.T) {
+	table := &schema.SQLTableStruct{
+		Name: "game",
+		Fields: []*schema.SQLFieldStruct{
+			&schema.SQLFieldStruct{Field: "game_id", Type: "integer", IsPrimaryKey: true},
+			&schema.SQLFieldStruct{Field: "updated_at", Type: "timestamp", Null: true},
+		},
+		PrimaryKeys: []string{"game_id"},
+	}
+	orders := []orderColumn{orderColumn{column: "updated_at"}, orderColumn{column: "game_id"}}
+	tests := []struct {
+		driver    string
+		values    map[string]interface{}
+		before    bool
+		condition string
+		args      []interface{}
+	}{
+		{
+			"postgres",
+			map[string]interface{}{"updated_at": "2018-07-20", "game_id": int64(1)},
+			false,
+			`(((t."updated_at" > $2 OR t."updated_at" IS NULL)) OR (t."updated_at" = $3 AND t."game_id" > $4))`,
+			[]interface{}{"2018-07-20", "2018-07-20", int64(1)},
+		},
+		{
+			"postgres",
+			map[string]interface{}{"updated_at": nil, "game_id": int64(1)},
+			false,
+			`((t."updated_at" IS NULL AND t."game_id" > $2))`,
+			[]interface{}{int64(1)},
+		},
+		{
+			"postgres",
+			map[string]interface{}{"updated_at": nil, "game_id": int64(1)},
+			true,
+			`((t."updated_at" IS NOT NULL) OR (t."updated_at" IS NULL AND t."game_id" < $2))`,
+			[]interface{}{int64(1)},
+		},
+		{
+			"mysql",
+			map[string]interface{}{"updated_at": "2018-07-20", "game_id": int64(1)},
+			true,
+			"(((t.`updated_at` < ? OR t.`updated_at` IS NULL)) OR (t.`updated_at` = ? AND t.`game_id` < ?))",
+			[]interface{}{"2018-07-20", "2018-07-20", int64(1)},
+		},
+		{
+			"mysql",
+			map[string]interface{}{"updated_at": nil, "game_id": int64(1)},
+			false,
+			"((t.`updated_at` IS NOT NULL) OR (t.`updated_at` IS NULL AND t.`game_id` > ?))",
+			[]interface{}{int64(1)},
+		},
+	}
+	for _, test := range tests {
+		condition, args := keysetCondition(getDialect(test.driver), table, orders, test.values, test.before, []interface{}{"where"})
+		if condition != test.condition {
+			t.Fatalf("Expected: \n%s\nGot:\n%s\n", test.condition, condition)
+		}
+		if !reflect.DeepEqual(args[1:], test.args) {
+			t.Fatalf("Expected args %v, got %v", test.args, args[1:])
+		}
+	}
+}
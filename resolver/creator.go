@@ -3,43 +3,64 @@ package resolver
 import (
 	"database/sql"
 	"fmt"
-	"log"
 	"strings"
 
 	"github.com/suppayami/goql/schema"
 )
 
-func makeCreator(db *sql.DB, table *schema.SQLTableStruct) func(map[string]interface{}) int64 {
-	return func(values map[string]interface{}) int64 {
+// makeCreator inserts rows of table, returning the first primary key column of the
+// inserted row as read by database, or nil when table has no primary key.
+func makeCreator(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) func(map[string]interface{}) (interface{}, error) {
+	return func(values map[string]interface{}) (interface{}, error) {
 		var sqlTxt string
 		fieldStatement := make([]string, 0)
 		valueStatement := make([]string, 0)
-		sqlTxt = fmt.Sprintf("INSERT INTO %s", table.Name)
-		for key, value := range values {
-			key = schema.GraphqlToSQLFieldName(key)
+		args := make([]interface{}, 0)
+		sqlTxt = fmt.Sprintf("INSERT INTO %s", d.quoteTable(table))
+		for field, value := range values {
+			key := schema.GraphqlToSQLFieldName(field)
 			if len(fmt.Sprintf("%v", value)) == 0 {
 				continue
 			}
-			if _, ok := value.(string); ok == true {
-				value = fmt.Sprintf("'%v'", value)
-			}
-			fieldStatement = append(fieldStatement, key)
-			valueStatement = append(valueStatement, fmt.Sprintf("%v", value))
+			args = append(args, d.timeValue(value))
+			fieldStatement = append(fieldStatement, d.quoteIdentifier(key))
+			valueStatement = append(valueStatement, d.bindValue(columns[field], d.placeholder(len(args))))
 		}
-		sqlTxt = fmt.Sprintf(
-			"%s (%s) VALUES (%s)",
-			sqlTxt,
-			strings.Join(fieldStatement, ", "),
-			strings.Join(valueStatement, ", "),
-		)
-		result, err := db.Exec(sqlTxt)
-		if err != nil {
-			log.Fatal(err)
+		if len(fieldStatement) == 0 {
+			sqlTxt = fmt.Sprintf("%s %s", sqlTxt, d.defaultValues)
+		} else {
+			sqlTxt = fmt.Sprintf(
+				"%s (%s) VALUES (%s)",
+				sqlTxt,
+				strings.Join(fieldStatement, ", "),
+				strings.Join(valueStatement, ", "),
+			)
+		}
+		if len(table.PrimaryKeys) == 0 {
+			_, err := db.Exec(sqlTxt, args...)
+			return nil, err
+		}
+		if d.returning {
+			// keys may be of any type, e.g. uuid
+			var insertedID interface{}
+			sqlTxt = fmt.Sprintf("%s RETURNING %s", sqlTxt, d.quoteIdentifier(table.PrimaryKeyField()))
+			if err := db.QueryRow(sqlTxt, args...).Scan(&insertedID); err != nil {
+				return nil, err
+			}
+			if b, ok := insertedID.([]byte); ok {
+				return string(b), nil
+			}
+			return insertedID, nil
 		}
-		lastInsertId, err := result.LastInsertId()
+		result, err := db.Exec(sqlTxt, args...)
 		if err != nil {
-			log.Fatal(err)
+			return nil, err
 		}
-		return lastInsertId
+		return result.LastInsertId()
 	}
 }
@@ -0,0 +1,17 @@
+package resolver
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func makeDeleter(db *sql.DB, d dialect, table *schema.SQLTableStruct) func(map[string]interface{}) error {
+	return func(keys map[string]interface{}) error {
+		whereStatement, args := keyCondition(d, keys, []interface{}{})
+		sqlTxt := fmt.Sprintf("DELETE FROM %s WHERE %s", d.quoteTable(table), whereStatement)
+		_, err := db.Exec(sqlTxt, args...)
+		return err
+	}
+}
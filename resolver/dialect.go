@@ -0,0 +1,162 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/suppayami/goql/schema"
+)
+
+// dialect describes how SQL statements differ between database servers.
+type dialect struct {
+	// returning is true when inserted keys are read back with INSERT ... RETURNING,
+	// for drivers which do not support sql.Result.LastInsertId.
+	returning bool
+
+	// numbered is true when placeholders are numbered ($1, $2...) instead of ?.
+	numbered bool
+
+	// quote wraps identifiers.
+	quote string
+
+	// jsonPathEquals formats a condition comparing the JSON value at a path of a
+	// column, from the column, path placeholder and value placeholder.
+	jsonPathEquals string
+
+	// defaultValues inserts a row made of default values only.
+	defaultValues string
+
+	// asGeoJSON and fromGeoJSON format a spatial column to GeoJSON and a GeoJSON
+	// placeholder to a geometry, spatial columns hold GeoJSON as is when empty.
+	asGeoJSON   string
+	fromGeoJSON string
+
+	// near formats a condition matching a spatial column within a distance in meters,
+	// from the column, GeoJSON point placeholder and distance placeholder.
+	near string
+
+	// match formats a full-text search of columns, from the columns, search placeholder
+	// and search mode.
+	match string
+
+	// nullsFirst is set when NULL is ordered before any value, instead of after.
+	nullsFirst bool
+
+	// timeLayout formats temporal values compared to columns, for databases storing
+	// them as text. They are bound as time.Time when empty.
+	timeLayout string
+
+	// booleanAggregates formats aggregate functions of boolean columns, by aggregate
+	// field, for databases which have no MIN and MAX of booleans.
+	booleanAggregates map[string]string
+}
+
+func getDialect(driver string) dialect {
+	switch driver {
+	case "postgres":
+		return dialect{
+			returning:      true,
+			numbered:       true,
+			quote:          `"`,
+			jsonPathEquals: "jsonb_path_query_first(%s::jsonb, %s::jsonpath) = %s::jsonb",
+			defaultValues:  "DEFAULT VALUES",
+			asGeoJSON:      "ST_AsGeoJSON(%s)",
+			fromGeoJSON:    "ST_GeomFromGeoJSON(%s)",
+			near:           "ST_DWithin(%s::geography, ST_GeomFromGeoJSON(%s)::geography, %s)",
+			booleanAggregates: map[string]string{
+				schema.AggregateMin: "bool_and(%s)",
+				schema.AggregateMax: "bool_or(%s)",
+			},
+		}
+	case "sqlite3":
+		return dialect{
+			quote:          `"`,
+			jsonPathEquals: "json_extract(%s, %s) = json_extract(%s, '$')",
+			defaultValues:  "DEFAULT VALUES",
+			nullsFirst:     true,
+			timeLayout:     "2006-01-02 15:04:05.999999999",
+		}
+	default:
+		return dialect{
+			quote:          "`",
+			jsonPathEquals: "JSON_EXTRACT(%s, %s) = CAST(%s AS JSON)",
+			defaultValues:  "() VALUES ()",
+			asGeoJSON:      "ST_AsGeoJSON(%s)",
+			fromGeoJSON:    "ST_GeomFromGeoJSON(%s)",
+			near:           "ST_Distance_Sphere(%s, ST_GeomFromGeoJSON(%s)) <= %s",
+			match:          "MATCH (%s) AGAINST (%s IN %s MODE)",
+			nullsFirst:     true,
+		}
+	}
+}
+
+// placeholder returns the bind parameter for the n-th argument, starting from 1.
+func (d dialect) placeholder(n int) string {
+	if d.numbered {
+		return fmt.Sprintf("$%d", n)
+	}
+	return "?"
+}
+
+// quoteIdentifier quotes a column name, escaping the quote character inside it.
+func (d dialect) quoteIdentifier(name string) string {
+	return fmt.Sprintf("%s%s%s", d.quote, strings.Replace(name, d.quote, d.quote+d.quote, -1), d.quote)
+}
+
+// selectList returns the columns of table to select, prefixed by alias if any,
+// converting spatial columns to GeoJSON.
+func (d dialect) selectList(table *schema.SQLTableStruct, columns map[string]schema.GraphqlType, alias string) string {
+	prefix := ""
+	if len(alias) > 0 {
+		prefix = fmt.Sprintf("%s.", alias)
+	}
+	hasGeoJSON := false
+	selected := make([]string, 0, len(table.Fields))
+	for _, field := range table.Fields {
+		column := fmt.Sprintf("%s%s", prefix, d.quoteIdentifier(field.Field))
+		if columns[schema.SQLToGraphqlFieldName(field.Field)] == schema.ScalarGeoJSON && len(d.asGeoJSON) > 0 {
+			hasGeoJSON = true
+			column = fmt.Sprintf("%s AS %s", fmt.Sprintf(d.asGeoJSON, column), d.quoteIdentifier(field.Field))
+		}
+		selected = append(selected, column)
+	}
+	if !hasGeoJSON {
+		return fmt.Sprintf("%s*", prefix)
+	}
+	return strings.Join(selected, ", ")
+}
+
+// aggregate formats the aggregate function named after the aggregate field function,
+// e.g. sum, of column holding values of gqlType.
+func (d dialect) aggregate(function string, gqlType schema.GraphqlType, column string) string {
+	if format, ok := d.booleanAggregates[function]; ok && gqlType == schema.ScalarBoolean {
+		return fmt.Sprintf(format, column)
+	}
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(function), column)
+}
+
+// timeValue returns value formatted in UTC by the time layout of the dialect if it is
+// a time.
+func (d dialect) timeValue(value interface{}) interface{} {
+	if t, ok := value.(time.Time); ok && len(d.timeLayout) > 0 {
+		return t.UTC().Format(d.timeLayout)
+	}
+	return value
+}
+
+// bindValue returns the placeholder of a value written to a column of gqlType.
+func (d dialect) bindValue(gqlType schema.GraphqlType, placeholder string) string {
+	if gqlType == schema.ScalarGeoJSON && len(d.fromGeoJSON) > 0 {
+		return fmt.Sprintf(d.fromGeoJSON, placeholder)
+	}
+	return placeholder
+}
+
+// quoteTable quotes a table name, qualified with its schema if any.
+func (d dialect) quoteTable(table *schema.SQLTableStruct) string {
+	if len(table.Schema) == 0 {
+		return d.quoteIdentifier(table.Name)
+	}
+	return fmt.Sprintf("%s.%s", d.quoteIdentifier(table.Schema), d.quoteIdentifier(table.Name))
+}
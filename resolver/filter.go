@@ -0,0 +1,155 @@
+package resolver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/suppayami/goql/schema"
+)
+
+// comparisonOperators maps comparison operators to SQL, "in" and "isNull" aside.
+var comparisonOperators = map[string]string{
+	schema.OperatorEq:   "=",
+	schema.OperatorNe:   "<>",
+	schema.OperatorGt:   ">",
+	schema.OperatorGte:  ">=",
+	schema.OperatorLt:   "<",
+	schema.OperatorLte:  "<=",
+	schema.OperatorLike: "LIKE",
+}
+
+// filterCondition compiles a table filter to a condition, appending its values to args.
+// An empty filter matches every row.
+func filterCondition(
+	d dialect,
+	table *schema.SQLTableStruct,
+	filter map[string]interface{},
+	args []interface{},
+) (string, []interface{}, error) {
+	conditions := make([]string, 0, len(filter))
+	for _, key := range sortedKeys(filter) {
+		var condition string
+		var err error
+		switch key {
+		case schema.FilterAnd, schema.FilterOr:
+			condition, args, err = combineFilters(d, table, key, filter[key], args)
+		case schema.FilterNot:
+			not, ok := filter[key].(map[string]interface{})
+			if !ok {
+				continue
+			}
+			condition, args, err = filterCondition(d, table, not, args)
+			condition = fmt.Sprintf("NOT %s", condition)
+		default:
+			field := table.FindField(schema.GraphqlToSQLFieldName(key))
+			comparison, ok := filter[key].(map[string]interface{})
+			if field == nil || !ok {
+				return "", nil, fmt.Errorf("unknown filter %s on %s", key, table.Name)
+			}
+			condition, args = comparisonCondition(d, field, comparison, args)
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		conditions = append(conditions, condition)
+	}
+	if len(conditions) == 0 {
+		return "(1 = 1)", args, nil
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, " AND ")), args, nil
+}
+
+// combineFilters joins a list of filters by AND or OR.
+func combineFilters(
+	d dialect,
+	table *schema.SQLTableStruct,
+	combinator string,
+	value interface{},
+	args []interface{},
+) (string, []interface{}, error) {
+	filters, _ := value.([]interface{})
+	conditions := make([]string, 0, len(filters))
+	for _, f := range filters {
+		filter, ok := f.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		condition, filterArgs, err := filterCondition(d, table, filter, args)
+		if err != nil {
+			return "", nil, err
+		}
+		args = filterArgs
+		conditions = append(conditions, condition)
+	}
+	if len(conditions) == 0 {
+		// no alternative matches nothing, no requirement matches everything
+		if combinator == schema.FilterOr {
+			return "(1 = 0)", args, nil
+		}
+		return "(1 = 1)", args, nil
+	}
+	separator := " AND "
+	if combinator == schema.FilterOr {
+		separator = " OR "
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, separator)), args, nil
+}
+
+// comparisonCondition compiles the operators compared to a column, joined by AND.
+func comparisonCondition(
+	d dialect,
+	field *schema.SQLFieldStruct,
+	comparison map[string]interface{},
+	args []interface{},
+) (string, []interface{}) {
+	column := d.quoteIdentifier(field.Field)
+	conditions := make([]string, 0, len(comparison))
+	for _, operator := range sortedKeys(comparison) {
+		value := comparison[operator]
+		if value == nil {
+			continue
+		}
+		switch operator {
+		case schema.OperatorIsNull:
+			if isNull, _ := value.(bool); isNull {
+				conditions = append(conditions, fmt.Sprintf("%s IS NULL", column))
+			} else {
+				conditions = append(conditions, fmt.Sprintf("%s IS NOT NULL", column))
+			}
+		case schema.OperatorIn:
+			values, _ := value.([]interface{})
+			if len(values) == 0 {
+				conditions = append(conditions, "1 = 0")
+				continue
+			}
+			placeholders := make([]string, 0, len(values))
+			for _, v := range values {
+				args = append(args, d.timeValue(v))
+				placeholders = append(placeholders, d.placeholder(len(args)))
+			}
+			conditions = append(conditions, fmt.Sprintf("%s IN (%s)", column, strings.Join(placeholders, ", ")))
+		default:
+			sqlOperator, ok := comparisonOperators[operator]
+			if !ok {
+				continue
+			}
+			args = append(args, d.timeValue(value))
+			conditions = append(conditions, fmt.Sprintf("%s %s %s", column, sqlOperator, d.placeholder(len(args))))
+		}
+	}
+	if len(conditions) == 0 {
+		return "(1 = 1)", args
+	}
+	return fmt.Sprintf("(%s)", strings.Join(conditions, " AND ")), args
+}
+
+// sortedKeys returns keys of m in order, so statements are built the same way every time.
+func sortedKeys(m map[string]interface{}) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
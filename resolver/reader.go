@@ -3,65 +3,361 @@ package resolver
 import (
 	"database/sql"
 	"fmt"
-	"log"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/suppayami/goql/schema"
 )
 
-func makeReader(db *sql.DB, table *schema.SQLTableStruct) func(map[string]interface{}) []map[string]string {
-	return func(wheres map[string]interface{}) []map[string]string {
-		var sqlTxt string
-		whereStatement := make([]string, 0)
-		rows := make([]map[string]string, 0)
-		sqlTxt = fmt.Sprintf("SELECT * FROM %s", table.Name)
-		for key, value := range wheres {
-			key = schema.GraphqlToSQLFieldName(key)
-			if len(fmt.Sprintf("%v", value)) == 0 {
-				continue
-			}
-			if _, ok := value.(string); ok == true {
-				value = fmt.Sprintf("'%v'", value)
-			}
-			if !strings.EqualFold(key, "first") && !strings.EqualFold(key, "offset") {
-				whereStatement = append(whereStatement, fmt.Sprintf("%s=%v", key, value))
-			}
+// makeReader reads rows of table, columns maps graphql field names to their types
+// so values are scanned as such.
+func makeReader(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) func(map[string]interface{}) ([]map[string]interface{}, error) {
+	return func(wheres map[string]interface{}) ([]map[string]interface{}, error) {
+		sqlTxt, args, err := readQuery(d, table, columns, wheres)
+		if err != nil {
+			return nil, err
 		}
-		if len(whereStatement) > 0 {
-			sqlTxt = fmt.Sprintf("%s WHERE", sqlTxt)
-			sqlTxt = fmt.Sprintf("%s %s", sqlTxt, strings.Join(whereStatement, " AND "))
+		return queryRows(db, sqlTxt, args, columns)
+	}
+}
+
+// readQuery returns the statement reading rows of table filtered, ordered and paginated
+// by the arguments, with its values.
+func readQuery(
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+	wheres map[string]interface{},
+) (string, []interface{}, error) {
+	// the relevance of full-text indexes is their sum
+	score, args, err := searchMatch(d, table, wheres, " + ", make([]interface{}, 0))
+	if err != nil {
+		return "", nil, err
+	}
+	selected := d.selectList(table, columns, "")
+	scoreColumn := schema.GraphqlToSQLFieldName(schema.SearchScoreField)
+	if len(score) > 0 {
+		selected = fmt.Sprintf("%s, %s AS %s", selected, score, d.quoteIdentifier(scoreColumn))
+	}
+	whereStatement, args, err := whereConditions(d, table, wheres, args)
+	if err != nil {
+		return "", nil, err
+	}
+	sqlTxt := fmt.Sprintf("SELECT %s FROM %s", selected, d.quoteTable(table))
+	if len(whereStatement) > 0 {
+		sqlTxt = fmt.Sprintf("%s WHERE", sqlTxt)
+		sqlTxt = fmt.Sprintf("%s %s", sqlTxt, strings.Join(whereStatement, " AND "))
+	}
+	order := orderClause(d, table, wheres[schema.OrderByArgumentName], "")
+	if len(score) > 0 && wheres[schema.OrderByArgumentName] == nil {
+		// most relevant rows first unless ordered otherwise
+		orders := append([]orderColumn{orderColumn{column: scoreColumn, desc: true}}, orderColumns(table, nil)...)
+		order = orderByColumns(d, orders, "")
+	}
+	sqlTxt = fmt.Sprintf("%s%s", sqlTxt, order)
+	sqlTxt, args = paginate(d, sqlTxt, args, wheres)
+	return sqlTxt, args, nil
+}
+
+// listArguments are the arguments of list queries which are not filtering a column,
+// even if the table has a column named alike.
+var listArguments = []string{
+	"first",
+	"offset",
+	schema.ConnectionLast,
+	schema.ConnectionAfter,
+	schema.ConnectionBefore,
+	schema.WhereArgumentName,
+	schema.OrderByArgumentName,
+	schema.SearchArgumentName,
+	schema.SearchModeArgumentName,
+	schema.GroupByArgumentName,
+}
+
+// isListArgument checks if the argument named key is an argument of the list queries
+// of table rather than a column.
+func isListArgument(table *schema.SQLTableStruct, key string) bool {
+	for _, name := range listArguments {
+		if key == name {
+			return true
 		}
-		if first, ok := wheres["first"]; ok {
-			sqlTxt = fmt.Sprintf("%s LIMIT %v", sqlTxt, first)
-			if offset, ok := wheres["offset"]; ok {
-				sqlTxt = fmt.Sprintf("%s OFFSET %v", sqlTxt, offset)
-			}
+	}
+	for _, field := range table.Fields {
+		if key == schema.JSONPathArgumentName(field.Field) || key == schema.NearArgumentName(field.Field) {
+			return true
 		}
-		sqlRows, err := db.Query(sqlTxt)
-		if err != nil {
-			log.Fatal(err)
+	}
+	return false
+}
+
+// whereConditions returns the conditions of the arguments filtering table, appending
+// their values to args: equality to columns, JSON path, near, where filters and search.
+func whereConditions(
+	d dialect,
+	table *schema.SQLTableStruct,
+	wheres map[string]interface{},
+	args []interface{},
+) ([]string, []interface{}, error) {
+	whereStatement := make([]string, 0)
+	for key, value := range wheres {
+		if value == nil || len(fmt.Sprintf("%v", value)) == 0 || isListArgument(table, key) {
+			continue
+		}
+		key = schema.GraphqlToSQLFieldName(key)
+		if table.FindField(key) != nil {
+			args = append(args, value)
+			whereStatement = append(whereStatement, fmt.Sprintf("%s = %s", d.quoteIdentifier(key), d.placeholder(len(args))))
+		}
+	}
+	for _, field := range table.Fields {
+		filter, ok := wheres[schema.JSONPathArgumentName(field.Field)].(map[string]interface{})
+		if !ok {
+			continue
 		}
-		defer sqlRows.Close()
-		cols, err := sqlRows.Columns()
+		args = append(args, filter["path"], filter["equals"])
+		whereStatement = append(whereStatement, fmt.Sprintf(
+			d.jsonPathEquals,
+			d.quoteIdentifier(field.Field),
+			d.placeholder(len(args)-1),
+			d.placeholder(len(args)),
+		))
+	}
+	for _, field := range table.Fields {
+		filter, ok := wheres[schema.NearArgumentName(field.Field)].(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if len(d.near) == 0 {
+			return nil, nil, fmt.Errorf("near filter is not supported by the database")
+		}
+		args = append(args, filter["point"], filter["radius"])
+		whereStatement = append(whereStatement, fmt.Sprintf(
+			d.near,
+			d.quoteIdentifier(field.Field),
+			d.placeholder(len(args)-1),
+			d.placeholder(len(args)),
+		))
+	}
+	if filter, ok := wheres[schema.WhereArgumentName].(map[string]interface{}); ok {
+		condition, filterArgs, err := filterCondition(d, table, filter, args)
 		if err != nil {
-			log.Fatal(err)
+			return nil, nil, err
 		}
-		for sqlRows.Next() {
-			columns := make([]sql.NullString, len(cols))
-			columnPointers := make([]interface{}, len(cols))
-			for i := range columns {
-				columnPointers[i] = &columns[i]
-			}
-			if err := sqlRows.Scan(columnPointers...); err != nil {
-				log.Fatal(err)
+		args = filterArgs
+		whereStatement = append(whereStatement, condition)
+	}
+	match, args, err := searchMatch(d, table, wheres, " OR ", args)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(match) > 0 {
+		whereStatement = append(whereStatement, match)
+	}
+	return whereStatement, args, nil
+}
+
+// makeJunctionReader reads rows of target.Table joined through junction.Table,
+// where junction is the relationship from the reading table to the junction table.
+func makeJunctionReader(
+	db *sql.DB,
+	d dialect,
+	junction *schema.SQLRelationshipStruct,
+	target *schema.SQLRelationshipStruct,
+	columns map[string]schema.GraphqlType,
+) func(interface{}, map[string]interface{}) ([]map[string]interface{}, error) {
+	return func(key interface{}, page map[string]interface{}) ([]map[string]interface{}, error) {
+		args := []interface{}{key}
+		sqlTxt := fmt.Sprintf(
+			"SELECT %s FROM %s t JOIN %s j ON j.%s = t.%s WHERE j.%s = %s",
+			d.selectList(target.Table, columns, "t"),
+			d.quoteTable(target.Table),
+			d.quoteTable(junction.Table),
+			d.quoteIdentifier(target.ForeignKey),
+			d.quoteIdentifier(target.LocalKey),
+			d.quoteIdentifier(junction.LocalKey),
+			d.placeholder(len(args)),
+		)
+		sqlTxt = fmt.Sprintf("%s%s", sqlTxt, orderClause(d, target.Table, nil, "t"))
+		sqlTxt, args = paginate(d, sqlTxt, args, page)
+		return queryRows(db, sqlTxt, args, columns)
+	}
+}
+
+// orderColumn is a column rows are ordered by.
+type orderColumn struct {
+	column string
+	desc   bool
+}
+
+// orderColumns returns the columns of the orderBy argument, then the primary key of
+// table so rows are in a stable order.
+func orderColumns(table *schema.SQLTableStruct, orderBy interface{}) []orderColumn {
+	orders := make([]orderColumn, 0)
+	ordered := make(map[string]bool)
+	items, _ := orderBy.([]interface{})
+	for _, item := range items {
+		order, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		column, _ := order[schema.OrderByField].(string)
+		direction, _ := order[schema.OrderByDirection].(string)
+		if table.FindField(column) == nil || ordered[column] {
+			continue
+		}
+		ordered[column] = true
+		orders = append(orders, orderColumn{column: column, desc: direction == "DESC"})
+	}
+	for _, key := range table.PrimaryKeys {
+		if !ordered[key] {
+			orders = append(orders, orderColumn{column: key})
+		}
+	}
+	return orders
+}
+
+// orderClause returns ORDER BY the orderBy argument, then by the primary key of table.
+// Columns are prefixed by alias if any.
+func orderClause(d dialect, table *schema.SQLTableStruct, orderBy interface{}, alias string) string {
+	return orderByColumns(d, orderColumns(table, orderBy), alias)
+}
+
+func orderByColumns(d dialect, orders []orderColumn, alias string) string {
+	prefix := ""
+	if len(alias) > 0 {
+		prefix = fmt.Sprintf("%s.", alias)
+	}
+	statements := make([]string, 0, len(orders))
+	for _, order := range orders {
+		direction := "ASC"
+		if order.desc {
+			direction = "DESC"
+		}
+		statements = append(statements, fmt.Sprintf("%s%s %s", prefix, d.quoteIdentifier(order.column), direction))
+	}
+	if len(statements) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(" ORDER BY %s", strings.Join(statements, ", "))
+}
+
+// paginate appends LIMIT and OFFSET from first and offset arguments.
+func paginate(d dialect, sqlTxt string, args []interface{}, page map[string]interface{}) (string, []interface{}) {
+	if first, ok := page["first"]; ok {
+		args = append(args, first)
+		sqlTxt = fmt.Sprintf("%s LIMIT %s", sqlTxt, d.placeholder(len(args)))
+		if offset, ok := page["offset"]; ok {
+			args = append(args, offset)
+			sqlTxt = fmt.Sprintf("%s OFFSET %s", sqlTxt, d.placeholder(len(args)))
+		}
+	}
+	return sqlTxt, args
+}
+
+func queryRows(db *sql.DB, sqlTxt string, args []interface{}, columns map[string]schema.GraphqlType) ([]map[string]interface{}, error) {
+	rows := make([]map[string]interface{}, 0)
+	sqlRows, err := db.Query(sqlTxt, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer sqlRows.Close()
+	cols, err := sqlRows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	for sqlRows.Next() {
+		values := make([]interface{}, len(cols))
+		valuePointers := make([]interface{}, len(cols))
+		for i := range values {
+			valuePointers[i] = &values[i]
+		}
+		if err := sqlRows.Scan(valuePointers...); err != nil {
+			return nil, err
+		}
+		m := make(map[string]interface{})
+		for i, colName := range cols {
+			fieldName := schema.SQLToGraphqlFieldName(colName)
+			value, err := convertValue(values[i], columns[fieldName])
+			if err != nil {
+				return nil, fmt.Errorf("column %s: %v", colName, err)
 			}
-			m := make(map[string]string)
-			for i, colName := range cols {
-				val := columnPointers[i].(*sql.NullString)
-				m[schema.SQLToGraphqlFieldName(colName)] = val.String
+			m[fieldName] = value
+		}
+		rows = append(rows, m)
+	}
+	if err := sqlRows.Err(); err != nil {
+		return nil, err
+	}
+	return rows, nil
+}
+
+// convertValue converts a value scanned from database to the Go type serialized
+// for gqlType, keeping NULL as nil. Columns without a known type are read as string.
+func convertValue(value interface{}, gqlType schema.GraphqlType) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	if b, ok := value.([]byte); ok {
+		if gqlType == schema.ScalarBase64 {
+			// copy as the driver may reuse its buffer
+			return append([]byte{}, b...), nil
+		}
+		if gqlType == schema.ScalarBoolean && len(b) == 1 && b[0] <= 1 {
+			// BIT(1) is returned as a raw byte
+			return b[0] == 1, nil
+		}
+		value = string(b)
+	}
+	switch gqlType {
+	case schema.ScalarInt:
+		switch v := value.(type) {
+		case int64:
+			return v, nil
+		case string:
+			return strconv.ParseInt(v, 10, 64)
+		}
+	case schema.ScalarFloat:
+		switch v := value.(type) {
+		case float64:
+			return v, nil
+		case int64:
+			return float64(v), nil
+		case string:
+			return strconv.ParseFloat(v, 64)
+		}
+	case schema.ScalarBoolean:
+		switch v := value.(type) {
+		case bool:
+			return v, nil
+		case int64:
+			return v != 0, nil
+		case string:
+			// TINYINT(1) may hold any integer, e.g. 2 or -1
+			if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+				return i != 0, nil
 			}
-			rows = append(rows, m)
+			return strconv.ParseBool(v)
+		}
+	case schema.ScalarDecimal, schema.ScalarBigInt:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64), nil
+		case int64:
+			return strconv.FormatInt(v, 10), nil
 		}
-		return rows
+	case schema.ScalarDate, schema.ScalarDateTime, schema.ScalarTime:
+		if t, ok := value.(time.Time); ok {
+			return t, nil
+		}
+	}
+	if t, ok := value.(time.Time); ok {
+		return t.Format(time.RFC3339Nano), nil
 	}
+	return fmt.Sprintf("%v", value), nil
 }
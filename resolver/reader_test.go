@@ -0,0 +1,47 @@
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func TestWhereConditionsNear(t *testing.T) {
+	table := &schema.SQLTableStruct{
+		Name: "store",
+		Fields: []*schema.SQLFieldStruct{
+			&schema.SQLFieldStruct{Field: "store_id", Type: "integer", IsPrimaryKey: true},
+			&schema.SQLFieldStruct{Field: "location", Type: "geometry"},
+		},
+		PrimaryKeys: []string{"store_id"},
+	}
+	point := `{"type":"Point","coordinates":[2.35,48.85]}`
+	wheres := map[string]interface{}{
+		schema.NearArgumentName("location"): map[string]interface{}{"point": point, "radius": 500.0},
+	}
+	tests := []struct {
+		driver    string
+		condition string
+	}{
+		{"postgres", `ST_DWithin("location"::geography, ST_GeomFromGeoJSON($2)::geography, $3)`},
+		{"mysql", "ST_Distance_Sphere(`location`, ST_GeomFromGeoJSON(?)) <= ?"},
+	}
+	for _, test := range tests {
+		conditions, args, err := whereConditions(getDialect(test.driver), table, wheres, []interface{}{"search"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(conditions, []string{test.condition}) {
+			t.Fatalf("Expected: \n%s\nGot:\n%v\n", test.condition, conditions)
+		}
+		expected := []interface{}{"search", point, 500.0}
+		if !reflect.DeepEqual(args, expected) {
+			t.Fatalf("Expected args %v, got %v", expected, args)
+		}
+	}
+
+	if _, _, err := whereConditions(getDialect("sqlite3"), table, wheres, nil); err == nil {
+		t.Fatal("Expected near filter to be rejected by SQLite")
+	}
+}
@@ -9,12 +9,33 @@ import (
 	"github.com/suppayami/goql/schema"
 )
 
+// graphqlTypes holds the named graphql-go types built from GraphqlSchema.
+type graphqlTypes struct {
+	objects map[string]*graphql.Object
+	enums   map[string]*graphql.Enum
+	inputs  map[string]*graphql.InputObject
+	columns map[string]map[string]schema.GraphqlType
+}
+
+// columnsOf returns the scalar type of each column of table, by graphql field name.
+func (types graphqlTypes) columnsOf(table *schema.SQLTableStruct) map[string]schema.GraphqlType {
+	return types.columns[schema.SQLToGraphqlObjectName(table.Name)]
+}
+
 // BuildSchema builds GraphQL handler & resolver
 func BuildSchema(db *sql.DB, sqlSchema schema.SQLSchemaStruct, graphqlSchema schema.GraphqlSchema) (*graphql.Schema, error) {
-	objectTypes := buildObjectTypes(db, sqlSchema, graphqlSchema)
+	types := graphqlTypes{
+		objects: make(map[string]*graphql.Object),
+		enums:   buildEnumTypes(graphqlSchema),
+		inputs:  make(map[string]*graphql.InputObject),
+		columns: buildColumnTypes(graphqlSchema),
+	}
+	buildInputTypes(graphqlSchema, types)
+	buildObjectTypes(db, sqlSchema, graphqlSchema, types)
+	buildMapObjectTypes(append(graphqlSchema.ConnectionTypes, graphqlSchema.AggregateTypes...), types)
 	schema, err := graphql.NewSchema(graphql.SchemaConfig{
-		Query:    buildQueryType(db, sqlSchema, graphqlSchema, objectTypes),
-		Mutation: buildMutationType(db, sqlSchema, graphqlSchema, objectTypes),
+		Query:    buildQueryType(db, sqlSchema, graphqlSchema, types),
+		Mutation: buildMutationType(db, sqlSchema, graphqlSchema, types),
 	})
 	if err != nil {
 		return nil, err
@@ -22,96 +43,178 @@ func BuildSchema(db *sql.DB, sqlSchema schema.SQLSchemaStruct, graphqlSchema sch
 	return &schema, nil
 }
 
+func buildEnumTypes(graphqlSchema schema.GraphqlSchema) map[string]*graphql.Enum {
+	enumTypes := make(map[string]*graphql.Enum)
+	for _, gql := range graphqlSchema.EnumTypes {
+		values := graphql.EnumValueConfigMap{}
+		names := schema.SQLToGraphqlEnumValues(gql.Values)
+		for i, value := range gql.Values {
+			values[names[i]] = &graphql.EnumValueConfig{
+				Value: value,
+			}
+		}
+		enumTypes[gql.Name] = graphql.NewEnum(graphql.EnumConfig{
+			Name:   gql.Name,
+			Values: values,
+		})
+	}
+	return enumTypes
+}
+
+func buildColumnTypes(graphqlSchema schema.GraphqlSchema) map[string]map[string]schema.GraphqlType {
+	columnTypes := make(map[string]map[string]schema.GraphqlType)
+	for _, gql := range graphqlSchema.ObjectTypes {
+		columns := make(map[string]schema.GraphqlType)
+		for _, field := range gql.Fields {
+			if field.Type != schema.ObjectType {
+				columns[field.Name] = field.Type
+			}
+		}
+		columnTypes[gql.Name] = columns
+	}
+	return columnTypes
+}
+
+func buildInputTypes(graphqlSchema schema.GraphqlSchema, types graphqlTypes) {
+	for _, inputType := range graphqlSchema.InputTypes {
+		gql := inputType
+		types.inputs[gql.Name] = graphql.NewInputObject(graphql.InputObjectConfig{
+			Name: gql.Name,
+			Fields: graphql.InputObjectConfigFieldMapThunk(func() graphql.InputObjectConfigFieldMap {
+				fields := graphql.InputObjectConfigFieldMap{}
+				for name, arg := range buildArguments(gql.Fields, types) {
+					fields[name] = &graphql.InputObjectFieldConfig{
+						Type:         arg.Type,
+						DefaultValue: arg.DefaultValue,
+					}
+				}
+				return fields
+			}),
+		})
+	}
+}
+
 func buildObjectTypes(
 	db *sql.DB,
 	sqlSchema schema.SQLSchemaStruct,
 	graphqlSchema schema.GraphqlSchema,
-) map[string]*graphql.Object {
-	objectTypes := make(map[string]*graphql.Object)
+	types graphqlTypes,
+) {
+	d := getDialect(sqlSchema.Driver)
 	// init object types
 	for _, gql := range graphqlSchema.ObjectTypes {
-		objectTypes[gql.Name] = graphql.NewObject(graphql.ObjectConfig{
-			Name:   gql.Name,
-			Fields: graphql.Fields{},
+		types.objects[gql.Name] = graphql.NewObject(graphql.ObjectConfig{
+			Name:        gql.Name,
+			Fields:      graphql.Fields{},
+			Description: gql.Description,
 		})
 	}
 	// setup fields
 	for _, gql := range graphqlSchema.ObjectTypes {
-		objectType := objectTypes[gql.Name]
-		gqlName := gql.Name
+		objectType := types.objects[gql.Name]
+		sqlTable := getSQLTable(sqlSchema, gql.Name)
 		for _, field := range gql.Fields {
 			f := field
+			var reader func(map[string]interface{}, map[string]interface{}) ([]map[string]interface{}, error)
+			if f.Type == schema.ObjectType {
+				reader = makeRelationshipReader(db, d, sqlTable, f.Name, types)
+			}
 			objectType.AddFieldConfig(f.Name, &graphql.Field{
-				Type: getGraphqlType(f, objectTypes),
+				Type:        getGraphqlType(f, types),
+				Args:        buildArguments(f.Arguments, types),
+				Description: f.Description,
 				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-					if obj, ok := p.Source.(map[string]string); ok == true {
+					if obj, ok := p.Source.(map[string]interface{}); ok == true {
 						if f.Type != schema.ObjectType {
 							return obj[f.Name], nil
 						}
 
 						// object type
-						table := getSQLTable(sqlSchema, schema.GraphqlToSQLTableName(f.ObjectType))
-						reader := makeReader(db, table)
-						m := make(map[string]interface{})
-						key := ""
-						if f.IsArray {
-							key = schema.PrimaryKey(schema.GraphqlToSQLTableName(gqlName))
-						} else {
-							key = schema.PrimaryKey(table.Name)
+						results, err := reader(obj, p.Args)
+						if err != nil {
+							return nil, err
 						}
-						m[key] = obj[schema.SQLToGraphqlFieldName(key)]
-						results := reader(m)
 						if f.IsArray {
 							return results, nil
 						}
-						return results[0], nil
+						return firstRow(results), nil
 					}
 					return nil, nil
 				},
 			})
 		}
 	}
-	return objectTypes
+}
+
+// buildMapObjectTypes builds object types which are not tables, their fields are
+// read from maps by the default resolver.
+func buildMapObjectTypes(objectTypes []schema.GraphqlObjectType, types graphqlTypes) {
+	for _, gql := range objectTypes {
+		types.objects[gql.Name] = graphql.NewObject(graphql.ObjectConfig{
+			Name:   gql.Name,
+			Fields: graphql.Fields{},
+		})
+	}
+	for _, gql := range objectTypes {
+		objectType := types.objects[gql.Name]
+		for _, field := range gql.Fields {
+			objectType.AddFieldConfig(field.Name, &graphql.Field{
+				Type: getGraphqlType(field, types),
+			})
+		}
+	}
 }
 
 func buildQueryType(
 	db *sql.DB,
 	sqlSchema schema.SQLSchemaStruct,
 	graphqlSchema schema.GraphqlSchema,
-	objectTypes map[string]*graphql.Object,
+	types graphqlTypes,
 ) *graphql.Object {
+	d := getDialect(sqlSchema.Driver)
 	rootQuery := graphql.NewObject(graphql.ObjectConfig{
 		Name:   graphqlSchema.QueryType.Name,
 		Fields: graphql.Fields{},
 	})
 	for _, queryField := range graphqlSchema.QueryType.Fields {
 		qf := queryField
-		table := getSQLTable(sqlSchema, schema.GraphqlToSQLTableName(qf.ObjectType))
-		reader := makeReader(db, table)
-		args := graphql.FieldConfigArgument{}
-		for _, argument := range qf.Arguments {
-			gql := schema.GraphqlField{
-				Name:       argument.Name,
-				Nullable:   argument.Nullable,
-				Type:       argument.Type,
-				ObjectType: argument.ObjectType,
-			}
-			args[argument.Name] = &graphql.ArgumentConfig{
-				Type: getGraphqlType(gql, objectTypes),
-			}
-			if argument.Nullable {
-				args[argument.Name].DefaultValue = argument.DefaultValue
-			}
+		if table := getConnectionTable(sqlSchema, qf.Name); table != nil {
+			reader := makeConnectionReader(db, d, table, types.columnsOf(table))
+			rootQuery.AddFieldConfig(qf.Name, &graphql.Field{
+				Type: getGraphqlType(qf, types),
+				Args: buildArguments(qf.Arguments, types),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return reader(p.Args)
+				},
+			})
+			continue
 		}
+		if table := getAggregateTable(sqlSchema, qf.Name); table != nil {
+			reader := makeAggregateReader(db, d, table, graphqlSchema.AggregateTypes)
+			rootQuery.AddFieldConfig(qf.Name, &graphql.Field{
+				Type: getGraphqlType(qf, types),
+				Args: buildArguments(qf.Arguments, types),
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return reader(p.Args, aggregateSelection(p.Info))
+				},
+			})
+			continue
+		}
+		table := getSQLTable(sqlSchema, qf.ObjectType)
+		reader := makeReader(db, d, table, types.columnsOf(table))
+		args := buildArguments(qf.Arguments, types)
 		rootQuery.AddFieldConfig(qf.Name, &graphql.Field{
-			Type: getGraphqlType(qf, objectTypes),
+			Type: getGraphqlType(qf, types),
 			Args: args,
 			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-				read := reader(p.Args)
+				read, err := reader(p.Args)
+				if err != nil {
+					return nil, err
+				}
 				if qf.IsArray {
 					return read, nil
 				}
-				return read[0], nil
+				return firstRow(read), nil
 			},
 		})
 	}
@@ -122,49 +225,201 @@ func buildMutationType(
 	db *sql.DB,
 	sqlSchema schema.SQLSchemaStruct,
 	graphqlSchema schema.GraphqlSchema,
-	objectTypes map[string]*graphql.Object,
+	types graphqlTypes,
 ) *graphql.Object {
+	d := getDialect(sqlSchema.Driver)
 	rootMutation := graphql.NewObject(graphql.ObjectConfig{
 		Name:   graphqlSchema.MutationType.Name,
 		Fields: graphql.Fields{},
 	})
 	for _, mutationField := range graphqlSchema.MutationType.Fields {
 		mf := mutationField
-		table := getSQLTable(sqlSchema, schema.GraphqlToSQLTableName(mf.ObjectType))
-		creator := makeCreator(db, table)
-		args := graphql.FieldConfigArgument{}
-		for _, argument := range mf.Arguments {
-			gql := schema.GraphqlField{
-				Name:       argument.Name,
-				Nullable:   argument.Nullable,
-				Type:       argument.Type,
-				ObjectType: argument.ObjectType,
-			}
-			args[argument.Name] = &graphql.ArgumentConfig{
-				Type: getGraphqlType(gql, objectTypes),
-			}
-			if argument.Nullable {
-				args[argument.Name].DefaultValue = argument.DefaultValue
-			}
+		table := getSQLTable(sqlSchema, mf.ObjectType)
+		args := buildArguments(mf.Arguments, types)
+		var resolve graphql.FieldResolveFn
+		switch mf.Name {
+		case schema.SQLToGraphqlUpdateFieldName(table.Name):
+			resolve = updateResolver(db, d, table, types.columnsOf(table))
+		case schema.SQLToGraphqlDeleteFieldName(table.Name):
+			resolve = deleteResolver(db, d, table, types.columnsOf(table))
+		default:
+			resolve = createResolver(db, d, table, types.columnsOf(table))
 		}
 		rootMutation.AddFieldConfig(mf.Name, &graphql.Field{
-			Type: getGraphqlType(mf, objectTypes),
-			Args: args,
-			Resolve: func(p graphql.ResolveParams) (interface{}, error) {
-				insertedID := creator(p.Args)
-				created := make(map[string]string)
-				for k, v := range p.Args {
-					created[k] = fmt.Sprintf("%v", v)
-				}
-				created[schema.SQLToGraphqlFieldName(schema.PrimaryKey(table.Name))] = fmt.Sprintf("%v", insertedID)
-				return created, nil
-			},
+			Type:    getGraphqlType(mf, types),
+			Args:    args,
+			Resolve: resolve,
 		})
 	}
 	return rootMutation
 }
 
-func getGraphqlType(gql schema.GraphqlField, objectTypes map[string]*graphql.Object) graphql.Output {
+func createResolver(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) graphql.FieldResolveFn {
+	creator := makeCreator(db, d, table, columns)
+	reader := makeReader(db, d, table, columns)
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		insertedID, err := creator(p.Args)
+		if err != nil {
+			return nil, err
+		}
+		created := make(map[string]interface{})
+		for k, v := range p.Args {
+			created[k] = v
+		}
+		if len(table.PrimaryKeys) == 0 {
+			return created, nil
+		}
+		primaryKey := schema.SQLToGraphqlFieldName(table.PrimaryKeyField())
+		if _, ok := created[primaryKey]; !ok {
+			created[primaryKey] = insertedID
+		}
+		// read the row back for the values set by database, e.g. defaults
+		keys, _ := splitPrimaryKeys(table, created)
+		for _, key := range keys {
+			if key == nil {
+				return created, nil
+			}
+		}
+		read, err := reader(keys)
+		if err != nil || len(read) == 0 {
+			return created, err
+		}
+		return read[0], nil
+	}
+}
+
+func updateResolver(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) graphql.FieldResolveFn {
+	updater := makeUpdater(db, d, table, columns)
+	reader := makeReader(db, d, table, columns)
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		keys, values := splitPrimaryKeys(table, p.Args)
+		if err := updater(keys, values); err != nil {
+			return nil, err
+		}
+		updated, err := reader(keys)
+		if err != nil {
+			return nil, err
+		}
+		return firstRow(updated), nil
+	}
+}
+
+func deleteResolver(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) graphql.FieldResolveFn {
+	deleter := makeDeleter(db, d, table)
+	reader := makeReader(db, d, table, columns)
+	return func(p graphql.ResolveParams) (interface{}, error) {
+		keys, _ := splitPrimaryKeys(table, p.Args)
+		deleted, err := reader(keys)
+		if err != nil || len(deleted) == 0 {
+			return nil, err
+		}
+		if err := deleter(keys); err != nil {
+			return nil, err
+		}
+		return deleted[0], nil
+	}
+}
+
+// splitPrimaryKeys separates primary key arguments from the other arguments.
+func splitPrimaryKeys(table *schema.SQLTableStruct, args map[string]interface{}) (map[string]interface{}, map[string]interface{}) {
+	keys := make(map[string]interface{})
+	values := make(map[string]interface{})
+	for k, v := range args {
+		values[k] = v
+	}
+	for _, key := range table.PrimaryKeys {
+		key = schema.SQLToGraphqlFieldName(key)
+		keys[key] = values[key]
+		delete(values, key)
+	}
+	return keys, values
+}
+
+func buildArguments(arguments []schema.GraphqlArgument, types graphqlTypes) graphql.FieldConfigArgument {
+	args := graphql.FieldConfigArgument{}
+	for _, argument := range arguments {
+		gql := schema.GraphqlField{
+			Name:       argument.Name,
+			Nullable:   argument.Nullable,
+			Type:       argument.Type,
+			ObjectType: argument.ObjectType,
+			IsArray:    argument.IsArray,
+		}
+		args[argument.Name] = &graphql.ArgumentConfig{
+			Type: getGraphqlType(gql, types),
+		}
+		if argument.Nullable && len(argument.DefaultValue) > 0 {
+			args[argument.Name].DefaultValue = argument.DefaultValue
+		}
+	}
+	return args
+}
+
+// makeRelationshipReader reads rows related to a source row through the relationship
+// of sqlTable named fieldName, joining through junction tables for many-to-many.
+func makeRelationshipReader(
+	db *sql.DB,
+	d dialect,
+	sqlTable *schema.SQLTableStruct,
+	fieldName string,
+	types graphqlTypes,
+) func(map[string]interface{}, map[string]interface{}) ([]map[string]interface{}, error) {
+	for _, relationship := range sqlTable.Relationships {
+		rel := relationship
+		if !rel.Table.IsManyToMany {
+			if schema.RelationshipFieldName(*rel) != fieldName {
+				continue
+			}
+			reader := makeReader(db, d, rel.Table, types.columnsOf(rel.Table))
+			return func(obj map[string]interface{}, args map[string]interface{}) ([]map[string]interface{}, error) {
+				key := obj[schema.SQLToGraphqlFieldName(rel.ForeignKey)]
+				if key == nil {
+					// a NULL foreign key relates to no row
+					return []map[string]interface{}{}, nil
+				}
+				return reader(map[string]interface{}{
+					rel.LocalKey: key,
+				})
+			}
+		}
+		for _, manyToMany := range rel.Table.Relationships {
+			if manyToMany.HasMany || manyToMany.ForeignKey == rel.LocalKey ||
+				schema.ManyToManyFieldName(*manyToMany) != fieldName {
+				continue
+			}
+			reader := makeJunctionReader(db, d, rel, manyToMany, types.columnsOf(manyToMany.Table))
+			return func(obj map[string]interface{}, args map[string]interface{}) ([]map[string]interface{}, error) {
+				return reader(obj[schema.SQLToGraphqlFieldName(rel.ForeignKey)], args)
+			}
+		}
+	}
+	panic(fmt.Sprintf("Relationship %s of table %s is missing", fieldName, sqlTable.Name))
+}
+
+// firstRow returns the first row read, or nil so a missing row resolves to null.
+func firstRow(rows []map[string]interface{}) interface{} {
+	if len(rows) == 0 {
+		return nil
+	}
+	return rows[0]
+}
+
+func getGraphqlType(gql schema.GraphqlField, types graphqlTypes) graphql.Output {
 	var gqlType graphql.Type
 	switch gql.Type {
 	case schema.ScalarID:
@@ -177,8 +432,28 @@ func getGraphqlType(gql schema.GraphqlField, objectTypes map[string]*graphql.Obj
 		gqlType = graphql.Boolean
 	case schema.ScalarString:
 		gqlType = graphql.String
+	case schema.ScalarDate:
+		gqlType = dateScalar
+	case schema.ScalarDateTime:
+		gqlType = dateTimeScalar
+	case schema.ScalarTime:
+		gqlType = timeScalar
+	case schema.ScalarJSON:
+		gqlType = jsonScalar
+	case schema.ScalarDecimal:
+		gqlType = decimalScalar
+	case schema.ScalarBigInt:
+		gqlType = bigIntScalar
+	case schema.ScalarBase64:
+		gqlType = base64Scalar
+	case schema.ScalarGeoJSON:
+		gqlType = geoJSONScalar
 	case schema.ObjectType:
-		gqlType = objectTypes[gql.ObjectType]
+		gqlType = types.objects[gql.ObjectType]
+	case schema.EnumType:
+		gqlType = types.enums[gql.ObjectType]
+	case schema.InputType:
+		gqlType = types.inputs[gql.ObjectType]
 	}
 	if !gql.Nullable {
 		gqlType = graphql.NewNonNull(gqlType)
@@ -198,11 +473,13 @@ func getGraphqlObjectType(graphqlSchema schema.GraphqlSchema, objectType string)
 	panic(fmt.Sprintf("ObjectType %s is missing", objectType))
 }
 
-func getSQLTable(sqlSchema schema.SQLSchemaStruct, tableName string) *schema.SQLTableStruct {
+// getSQLTable returns the table of the object type named objectType, which
+// SQLToGraphqlSchema guarantees to be unique.
+func getSQLTable(sqlSchema schema.SQLSchemaStruct, objectType string) *schema.SQLTableStruct {
 	for _, sqlTable := range sqlSchema.Tables {
-		if strings.EqualFold(sqlTable.Name, tableName) {
+		if schema.SQLToGraphqlObjectName(sqlTable.Name) == objectType {
 			return sqlTable
 		}
 	}
-	panic(fmt.Sprintf("Table %s is missing", tableName))
+	panic(fmt.Sprintf("Table of %s is missing", objectType))
 }
@@ -0,0 +1,684 @@
+package resolver_test
+
+import (
+	"database/sql"
+	"encoding/json"
+	"testing"
+
+	"github.com/graphql-go/graphql"
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/suppayami/goql/resolver"
+	"github.com/suppayami/goql/schema"
+)
+
+const gamesFixture = `
+CREATE TABLE developer (
+	developer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT
+);
+CREATE TABLE game (
+	game_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT,
+	developer_id INTEGER,
+	release_date DATE,
+	updated_at DATETIME,
+	metadata JSON
+);
+CREATE TABLE genre (
+	genre_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT
+);
+CREATE TABLE game_genre (
+	game_id INTEGER NOT NULL,
+	genre_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, genre_id)
+);
+INSERT INTO developer VALUES (1, 'Valve'), (2, 'CD PROJEKT RED');
+INSERT INTO game VALUES
+	(1, 'The Witcher 3', 2, '2015-05-18', '2018-07-20 09:30:00', '{"engine":"REDengine","dlc":["Blood and Wine"]}'),
+	(2, 'Dota 2', 1, '2013-07-09', NULL, '{"engine":"Source 2"}'),
+	(3, 'Half-Life', 1, NULL, NULL, NULL);
+INSERT INTO genre VALUES (1, 'RPG'), (2, 'Strategy'), (3, 'MOBA');
+INSERT INTO game_genre VALUES (1, 1), (2, 2), (2, 3), (3, 1);
+CREATE VIEW game_summary AS
+	SELECT game.name, developer.name AS developer_name, game.developer_id
+	FROM game JOIN developer ON developer.developer_id = game.developer_id;`
+
+// buildSchema serves an in-memory SQLite database created by fixture, hooks may
+// describe what SQLite cannot before the Graphql schema is built.
+func buildSchema(t *testing.T, fixture string, hooks ...func(*schema.SQLSchemaStruct)) (*sql.DB, *graphql.Schema) {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatal(err)
+	}
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, hook := range hooks {
+		hook(&sqlSchema)
+	}
+	graphqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gqlSchema, err := resolver.BuildSchema(db, sqlSchema, graphqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, gqlSchema
+}
+
+func assertQuery(t *testing.T, gqlSchema *graphql.Schema, query string, expected string) {
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: query,
+	})
+	if result.HasErrors() {
+		t.Fatalf("Unexpected errors: %v", result.Errors)
+	}
+	got, err := json.Marshal(result.Data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != expected {
+		t.Fatalf("Expected: \n%s\nGot:\n%s\n", expected, got)
+	}
+}
+
+func TestQueryRelationships(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ games(first: 2) { name developer { name } } }`,
+		`{"games":[{"developer":{"name":"CD PROJEKT RED"},"name":"The Witcher 3"},{"developer":{"name":"Valve"},"name":"Dota 2"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ developer(developerId: 1) { name games { name } } }`,
+		`{"developer":{"games":[{"name":"Dota 2"},{"name":"Half-Life"}],"name":"Valve"}}`,
+	)
+}
+
+func TestQueryManyToMany(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ game(gameId: 2) { genres { name } } }`,
+		`{"game":{"genres":[{"name":"Strategy"},{"name":"MOBA"}]}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ genre(genreId: 1) { games(first: 1, offset: 1) { name } } }`,
+		`{"genre":{"games":[{"name":"Half-Life"}]}}`,
+	)
+}
+
+func TestCreateMutation(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`mutation { createDeveloper(name: "Grinding Gear Games") { developerId name } }`,
+		`{"createDeveloper":{"developerId":"3","name":"Grinding Gear Games"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { createDeveloper(name: "Bob's Games') --") { name } }`,
+		`{"createDeveloper":{"name":"Bob's Games') --"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ developer(developerId: "4") { name } }`,
+		`{"developer":{"name":"Bob's Games') --"}}`,
+	)
+}
+
+func TestCreateWithoutPrimaryKey(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `CREATE TABLE event (name TEXT NOT NULL, payload TEXT);`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`mutation { createEvent(name: "login") { name payload } }`,
+		`{"createEvent":{"name":"login","payload":null}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ events { name } }`,
+		`{"events":[{"name":"login"}]}`,
+	)
+}
+
+func TestResolverErrors(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ developer(developerId: 99) { name } }`,
+		`{"developer":null}`,
+	)
+
+	if _, err := db.Exec("DROP TABLE game"); err != nil {
+		t.Fatal(err)
+	}
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `{ games { name } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected query on a dropped table to return errors")
+	}
+}
+
+func TestUpdateDeleteMutations(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`mutation { updateGame(gameId: "3", name: "Half-Life 2") { gameId name developer { name } } }`,
+		`{"updateGame":{"developer":{"name":"Valve"},"gameId":"3","name":"Half-Life 2"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { deleteGame(gameId: "3") { name } }`,
+		`{"deleteGame":{"name":"Half-Life 2"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { deleteGame(gameId: "3") { name } }`,
+		`{"deleteGame":null}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games { name } }`,
+		`{"games":[{"name":"The Witcher 3"},{"name":"Dota 2"}]}`,
+	)
+}
+
+func TestCompositePrimaryKey(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ gameGenre(gameId: 2, genreId: 3) { game { name } genre { name } } }`,
+		`{"gameGenre":{"game":{"name":"Dota 2"},"genre":{"name":"MOBA"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { deleteGameGenre(gameId: 2, genreId: 3) { genre { name } } }`,
+		`{"deleteGameGenre":{"genre":{"name":"MOBA"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ game(gameId: 2) { genres { name } } }`,
+		`{"game":{"genres":[{"name":"Strategy"}]}}`,
+	)
+}
+
+func TestQueryView(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ gameSummaries(first: 1) { name developerName developer { name } } }`,
+		`{"gameSummaries":[{"developer":{"name":"CD PROJEKT RED"},"developerName":"CD PROJEKT RED","name":"The Witcher 3"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ developer(developerId: 2) { gameSummaries { name } } }`,
+		`{"developer":{"gameSummaries":[{"name":"The Witcher 3"}]}}`,
+	)
+	if _, ok := gqlSchema.MutationType().Fields()["createGameSummary"]; ok {
+		t.Fatal("Expected no create mutation for a view")
+	}
+}
+
+func TestTemporalScalars(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ game(gameId: 1) { releaseDate updatedAt } }`,
+		`{"game":{"releaseDate":"2015-05-18","updatedAt":"2018-07-20T09:30:00Z"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { updateGame(gameId: 3, releaseDate: "1998-11-19", updatedAt: "2018-07-20T11:30:00+02:00") { releaseDate updatedAt } }`,
+		`{"updateGame":{"releaseDate":"1998-11-19","updatedAt":"2018-07-20T09:30:00Z"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { createGame(name: "Portal", updatedAt: "2018-07-20T09:30:01Z") { gameId } }`,
+		`{"createGame":{"gameId":"4"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {updatedAt: {eq: "2018-07-20T09:30:00Z"}}) { name } }`,
+		`{"games":[{"name":"The Witcher 3"},{"name":"Half-Life"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {updatedAt: {gt: "2018-07-20T09:30:00Z"}}, orderBy: [{field: UPDATED_AT, direction: DESC}]) { name } }`,
+		`{"games":[{"name":"Portal"}]}`,
+	)
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `mutation { updateGame(gameId: 3, releaseDate: "next tuesday") { releaseDate } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected invalid date to be rejected")
+	}
+}
+
+func TestEnumType(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE platform (platform_id INTEGER PRIMARY KEY, kind TEXT);
+		INSERT INTO platform VALUES (1, 'pc'), (2, 'home console');`, func(sqlSchema *schema.SQLSchemaStruct) {
+		// SQLite has no enum columns, describe kind as MySQL would
+		sqlSchema.Tables[0].Fields[1].Type = "enum('pc','home console','handheld')"
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ platforms { kind } }`,
+		`{"platforms":[{"kind":"PC"},{"kind":"HOME_CONSOLE"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { updatePlatform(platformId: 1, kind: HANDHELD) { kind } }`,
+		`{"updatePlatform":{"kind":"HANDHELD"}}`,
+	)
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `mutation { updatePlatform(platformId: 1, kind: ARCADE) { kind } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected unknown enum value to be rejected")
+	}
+}
+
+func TestEnumCollisions(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE platform (platform_id INTEGER PRIMARY KEY, kind TEXT);
+		INSERT INTO platform VALUES (1, 'home console'), (2, 'home_console');`, func(sqlSchema *schema.SQLSchemaStruct) {
+		// SQLite has no enum columns, describe kind as MySQL would
+		sqlSchema.Tables[0].Fields[1].Type = "enum('home console','home_console')"
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ platforms { kind } }`,
+		`{"platforms":[{"kind":"HOME_CONSOLE"},{"kind":"HOME_CONSOLE_2"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { updatePlatform(platformId: 1, kind: HOME_CONSOLE_2) { kind } }`,
+		`{"updatePlatform":{"kind":"HOME_CONSOLE_2"}}`,
+	)
+}
+
+func TestJSONScalar(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ game(gameId: 1) { metadata } }`,
+		`{"game":{"metadata":{"dlc":["Blood and Wine"],"engine":"REDengine"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(metadataPath: {path: "$.engine", equals: "Source 2"}) { name } }`,
+		`{"games":[{"name":"Dota 2"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { updateGame(gameId: 3, metadata: {engine: "GoldSrc", year: 1998}) { metadata } }`,
+		`{"updateGame":{"metadata":{"engine":"GoldSrc","year":1998}}}`,
+	)
+}
+
+func TestTypedRows(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE developer (developer_id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE review (
+			review_id INTEGER PRIMARY KEY,
+			developer_id INTEGER,
+			rating INTEGER,
+			score REAL,
+			recommended BOOLEAN,
+			note TEXT
+		);
+		INSERT INTO developer VALUES (1, 'Valve');
+		INSERT INTO review VALUES (1, 1, 9, 8.5, 1, 'Great'), (2, NULL, NULL, NULL, NULL, NULL);`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ reviews { reviewId rating score recommended note developer { name } } }`,
+		`{"reviews":[`+
+			`{"developer":{"name":"Valve"},"note":"Great","rating":9,"recommended":true,"reviewId":"1","score":8.5},`+
+			`{"developer":null,"note":null,"rating":null,"recommended":null,"reviewId":"2","score":null}]}`,
+	)
+}
+
+func TestBooleanIntegers(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE setting (setting_id INTEGER PRIMARY KEY, enabled TEXT);
+		INSERT INTO setting VALUES (1, '2'), (2, '-1'), (3, '0'), (4, 'true');`, func(sqlSchema *schema.SQLSchemaStruct) {
+		// MySQL returns TINYINT(1) as text, which may hold any integer
+		sqlSchema.TypeMapper = schema.ColumnTypeMapper{
+			Columns:  map[string]schema.GraphqlType{"setting.enabled": schema.ScalarBoolean},
+			Fallback: sqlSchema.TypeMapper,
+		}
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ settings { enabled } }`,
+		`{"settings":[{"enabled":true},{"enabled":true},{"enabled":false},{"enabled":true}]}`,
+	)
+}
+
+func TestNumericScalars(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE account (account_id INTEGER PRIMARY KEY, balance DECIMAL(12,2), visits BIGINT);
+		INSERT INTO account VALUES (1, 19.99, 9007199254740993);`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ account(accountId: 1) { balance visits } }`,
+		`{"account":{"balance":"19.99","visits":"9007199254740993"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { updateAccount(accountId: 1, balance: "1050.5", visits: 9223372036854775807) { balance visits } }`,
+		`{"updateAccount":{"balance":"1050.5","visits":"9223372036854775807"}}`,
+	)
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `mutation { updateAccount(accountId: 1, visits: "1.5") { visits } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected fractional BigInt to be rejected")
+	}
+}
+
+func TestBase64Scalar(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE asset (asset_id INTEGER PRIMARY KEY, data BLOB);
+		INSERT INTO asset VALUES (1, x'00ff10');`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ asset(assetId: 1) { data } }`,
+		`{"asset":{"data":"AP8Q"}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { createAsset(data: "3q2+7w==") { data } }`,
+		`{"createAsset":{"data":"3q2+7w=="}}`,
+	)
+	var data []byte
+	if err := db.QueryRow("SELECT data FROM asset WHERE asset_id = 2").Scan(&data); err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "\xde\xad\xbe\xef" {
+		t.Fatalf("Expected raw bytes to be stored, got %x", data)
+	}
+}
+
+func TestDescriptions(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture, func(sqlSchema *schema.SQLSchemaStruct) {
+		// SQLite has no comments, describe game as MySQL would
+		var game *schema.SQLTableStruct
+		for _, table := range sqlSchema.Tables {
+			if table.Name == "game" {
+				game = table
+			}
+		}
+		game.Comment = "Games on sale"
+		game.FindField("name").Comment = "Title of the game"
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ __type(name: "Game") { description fields { name description } } }`,
+		`{"__type":{"description":"Games on sale","fields":[`+
+			`{"description":"","name":"developer"},{"description":"","name":"gameId"},`+
+			`{"description":"","name":"genres"},{"description":"","name":"metadata"},`+
+			`{"description":"Title of the game","name":"name"},`+
+			`{"description":"","name":"releaseDate"},{"description":"","name":"updatedAt"}]}}`,
+	)
+}
+
+func TestCreateDefaults(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE item (
+			item_id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL DEFAULT 'unnamed',
+			price REAL NOT NULL DEFAULT 0,
+			label TEXT GENERATED ALWAYS AS (name || ': ' || price) VIRTUAL
+		);`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`mutation { createItem(name: "Potion") { itemId name price label } }`,
+		`{"createItem":{"itemId":"1","label":"Potion: 0.0","name":"Potion","price":0}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { createItem { itemId name } }`,
+		`{"createItem":{"itemId":"2","name":"unnamed"}}`,
+	)
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `mutation { createItem(label: "Elixir") { itemId } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected generated column to be rejected")
+	}
+}
+
+func TestGeoJSONScalar(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE store (store_id INTEGER PRIMARY KEY, location POINT);
+		INSERT INTO store VALUES (1, '{"type":"Point","coordinates":[2.35,48.85]}');`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ store(storeId: 1) { location } }`,
+		`{"store":{"location":{"coordinates":[2.35,48.85],"type":"Point"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`mutation { createStore(location: {type: "Point", coordinates: [-0.12, 51.5]}) { location } }`,
+		`{"createStore":{"location":{"coordinates":[-0.12,51.5],"type":"Point"}}}`,
+	)
+	for _, query := range []string{
+		`mutation { createStore(location: {coordinates: [-0.12, 51.5]}) { location } }`,
+		// SQLite has no spatial functions
+		`{ stores(locationNear: {point: {type: "Point", coordinates: [2.35, 48.85]}, radius: 1000}) { storeId } }`,
+	} {
+		result := graphql.Do(graphql.Params{
+			Schema:        *gqlSchema,
+			RequestString: query,
+		})
+		if !result.HasErrors() {
+			t.Fatalf("Expected %s to fail", query)
+		}
+	}
+}
+
+func TestWhereFilter(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ games(where: {name: {like: "%a%"}, releaseDate: {isNull: false}}) { name } }`,
+		`{"games":[{"name":"Dota 2"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {or: [{gameId: {in: [1, 3]}}, {releaseDate: {gt: "2014-01-01"}}], not: {name: {eq: "Half-Life"}}}) { name } }`,
+		`{"games":[{"name":"The Witcher 3"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {developerId: {eq: 1}, updatedAt: {isNull: true}}) { name } }`,
+		`{"games":[{"name":"Dota 2"},{"name":"Half-Life"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {or: []}) { name } }`,
+		`{"games":[]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {updatedAt: {eq: "2018-07-20T09:30:00Z"}}) { name } }`,
+		`{"games":[{"name":"The Witcher 3"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {updatedAt: {gte: "2018-07-20T11:30:00+02:00"}}) { name } }`,
+		`{"games":[{"name":"The Witcher 3"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(where: {updatedAt: {in: ["2018-07-20T09:30:00Z"], lt: "2018-07-20T09:30:01Z"}}) { name } }`,
+		`{"games":[{"name":"The Witcher 3"}]}`,
+	)
+}
+
+func TestOrderBy(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ games(orderBy: [{field: NAME, direction: DESC}]) { name } }`,
+		`{"games":[{"name":"The Witcher 3"},{"name":"Half-Life"},{"name":"Dota 2"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ games(first: 1, offset: 1, orderBy: [{field: DEVELOPER_ID}]) { name } }`,
+		`{"games":[{"name":"Half-Life"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gameSummaries(orderBy: [{field: DEVELOPER_NAME}, {field: NAME, direction: DESC}]) { name } }`,
+		`{"gameSummaries":[{"name":"The Witcher 3"},{"name":"Half-Life"},{"name":"Dota 2"}]}`,
+	)
+}
+
+func TestArgumentColumns(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE person (person_id INTEGER PRIMARY KEY, first TEXT, last TEXT, offset INTEGER);
+		INSERT INTO person VALUES (1, 'Ada', 'Lovelace', 0), (2, 'Alan', 'Turing', 10);`,
+		func(sqlSchema *schema.SQLSchemaStruct) {
+			sqlSchema.Connections = true
+		})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ people { first last } }`,
+		`{"people":[{"first":"Ada","last":"Lovelace"},{"first":"Alan","last":"Turing"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ people(first: 1, where: {first: {eq: "Alan"}}) { last } }`,
+		`{"people":[{"last":"Turing"}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ peopleConnection(last: 1) { edges { node { first } } } }`,
+		`{"peopleConnection":{"edges":[{"node":{"first":"Alan"}}]}}`,
+	)
+}
+
+func TestConnections(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture, func(sqlSchema *schema.SQLSchemaStruct) {
+		sqlSchema.Connections = true
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 2) { edges { cursor node { name } } pageInfo { hasNextPage hasPreviousPage endCursor } } }`,
+		`{"gamesConnection":{"edges":[{"cursor":"eyJnYW1lX2lkIjoiMSJ9","node":{"name":"The Witcher 3"}},{"cursor":"eyJnYW1lX2lkIjoiMiJ9","node":{"name":"Dota 2"}}],"pageInfo":{"endCursor":"eyJnYW1lX2lkIjoiMiJ9","hasNextPage":true,"hasPreviousPage":false}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 2, after: "eyJnYW1lX2lkIjoiMiJ9") { edges { node { name } } pageInfo { hasNextPage hasPreviousPage } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Half-Life"}}],"pageInfo":{"hasNextPage":false,"hasPreviousPage":true}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(last: 2, orderBy: [{field: NAME, direction: DESC}]) { edges { node { name } } pageInfo { hasNextPage hasPreviousPage startCursor } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Half-Life"}},{"node":{"name":"Dota 2"}}],"pageInfo":{"hasNextPage":false,"hasPreviousPage":true,"startCursor":"eyJnYW1lX2lkIjoiMyIsIm5hbWUiOiJIYWxmLUxpZmUifQ=="}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(last: 1, before: "eyJnYW1lX2lkIjoiMyIsIm5hbWUiOiJIYWxmLUxpZmUifQ==", orderBy: [{field: NAME, direction: DESC}]) { edges { node { name } } pageInfo { hasNextPage hasPreviousPage } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"The Witcher 3"}}],"pageInfo":{"hasNextPage":true,"hasPreviousPage":false}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 5, where: {developerId: {eq: 1}}) { edges { node { name } } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Dota 2"}},{"node":{"name":"Half-Life"}}]}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection { edges { node { name } } pageInfo { hasNextPage } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"The Witcher 3"}},{"node":{"name":"Dota 2"}},{"node":{"name":"Half-Life"}}],"pageInfo":{"hasNextPage":false}}}`,
+	)
+
+	// SQLite orders NULL first
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 1, orderBy: [{field: UPDATED_AT}]) { edges { node { name } } pageInfo { endCursor } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Dota 2"}}],"pageInfo":{"endCursor":"eyJnYW1lX2lkIjoiMiIsInVwZGF0ZWRfYXQiOm51bGx9"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 1, after: "eyJnYW1lX2lkIjoiMiIsInVwZGF0ZWRfYXQiOm51bGx9", orderBy: [{field: UPDATED_AT}]) { edges { node { name } } pageInfo { endCursor } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Half-Life"}}],"pageInfo":{"endCursor":"eyJnYW1lX2lkIjoiMyIsInVwZGF0ZWRfYXQiOm51bGx9"}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 1, after: "eyJnYW1lX2lkIjoiMyIsInVwZGF0ZWRfYXQiOm51bGx9", orderBy: [{field: UPDATED_AT}]) { edges { node { name } } pageInfo { endCursor hasNextPage } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"The Witcher 3"}}],"pageInfo":{"endCursor":"eyJnYW1lX2lkIjoiMSIsInVwZGF0ZWRfYXQiOiIyMDE4LTA3LTIwVDA5OjMwOjAwWiJ9","hasNextPage":false}}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 1, after: "eyJnYW1lX2lkIjoiMSIsInVwZGF0ZWRfYXQiOiIyMDE4LTA3LTIwVDA5OjMwOjAwWiJ9", orderBy: [{field: UPDATED_AT}]) { edges { node { name } } } }`,
+		`{"gamesConnection":{"edges":[]}}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(last: 2, before: "eyJnYW1lX2lkIjoiMSIsInVwZGF0ZWRfYXQiOiIyMDE4LTA3LTIwVDA5OjMwOjAwWiJ9", orderBy: [{field: UPDATED_AT}]) { edges { node { name } } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Dota 2"}},{"node":{"name":"Half-Life"}}]}}`,
+	)
+
+	// the cursor holds the order values, the row it was read from may be deleted
+	if _, err := db.Exec("DELETE FROM game WHERE game_id = 2"); err != nil {
+		t.Fatal(err)
+	}
+	assertQuery(t, gqlSchema,
+		`{ gamesConnection(first: 5, after: "eyJnYW1lX2lkIjoiMiIsIm5hbWUiOiJEb3RhIDIifQ==", orderBy: [{field: NAME}]) { edges { node { name } } } }`,
+		`{"gamesConnection":{"edges":[{"node":{"name":"Half-Life"}},{"node":{"name":"The Witcher 3"}}]}}`,
+	)
+}
+
+func TestAggregates(t *testing.T) {
+	db, gqlSchema := buildSchema(t, `
+		CREATE TABLE game (game_id INTEGER PRIMARY KEY, name TEXT, genre TEXT, price DECIMAL(10,2), rating REAL);
+		INSERT INTO game VALUES
+			(1, 'The Witcher 3', 'RPG', 40, 9.5),
+			(2, 'Dota 2', 'MOBA', 0, 8.5),
+			(3, 'Half-Life', 'FPS', 10, 9.0),
+			(4, 'Diablo', 'RPG', 20, NULL);`)
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ gamesAggregate { count sum { price rating } avg { price } min { name } max { price } group { genre } } }`,
+		`{"gamesAggregate":[{"avg":{"price":"17.5"},"count":4,"group":null,"max":{"price":"40"},"min":{"name":"Diablo"},"sum":{"price":"70","rating":27}}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesAggregate(groupBy: [GENRE]) { count group { genre } sum { price } } }`,
+		`{"gamesAggregate":[{"count":1,"group":{"genre":"FPS"},"sum":{"price":"10"}},{"count":1,"group":{"genre":"MOBA"},"sum":{"price":"0"}},{"count":2,"group":{"genre":"RPG"},"sum":{"price":"60"}}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesAggregate(where: {rating: {gte: 9}}) { count } }`,
+		`{"gamesAggregate":[{"count":2}]}`,
+	)
+	assertQuery(t, gqlSchema,
+		`{ gamesAggregate { ...prices ... on GameAggregate { min { name } } } } fragment prices on GameAggregate { max { price } }`,
+		`{"gamesAggregate":[{"max":{"price":"40"},"min":{"name":"Diablo"}}]}`,
+	)
+}
+
+func TestSearch(t *testing.T) {
+	db, gqlSchema := buildSchema(t, gamesFixture, func(sqlSchema *schema.SQLSchemaStruct) {
+		// SQLite has no FULLTEXT indexes, describe name as MySQL would
+		for _, table := range sqlSchema.Tables {
+			if table.Name == "game" {
+				table.FullTextIndexes = []*schema.SQLIndexStruct{
+					&schema.SQLIndexStruct{Name: "name", Fields: []string{"name"}},
+				}
+			}
+		}
+	})
+	defer db.Close()
+
+	assertQuery(t, gqlSchema,
+		`{ games(first: 1) { name searchScore } }`,
+		`{"games":[{"name":"The Witcher 3","searchScore":null}]}`,
+	)
+	result := graphql.Do(graphql.Params{
+		Schema:        *gqlSchema,
+		RequestString: `{ games(search: "witcher", searchMode: BOOLEAN) { name searchScore } }`,
+	})
+	if !result.HasErrors() {
+		t.Fatal("Expected search to be rejected by SQLite")
+	}
+}
@@ -0,0 +1,310 @@
+package resolver
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// Layouts accepted when reading temporal values from the database or clients.
+var (
+	dateTimeLayouts = []string{
+		time.RFC3339Nano,
+		"2006-01-02 15:04:05.999999999-07:00",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02",
+	}
+	timeLayouts = []string{
+		"15:04:05.999999999",
+		"15:04:05.999999999Z07:00",
+	}
+)
+
+// Patterns of the numbers accepted by string-backed numeric scalars.
+var (
+	decimalPattern = regexp.MustCompile(`^[-+]?(\d+\.?\d*|\.\d+)$`)
+	bigIntPattern  = regexp.MustCompile(`^[-+]?\d+$`)
+)
+
+// dateScalar is a RFC 3339 full-date, e.g. 2018-07-20.
+var dateScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Date",
+	Description: "A RFC 3339 full-date, e.g. 2018-07-20",
+	Serialize: func(value interface{}) interface{} {
+		return formatTime(value, dateTimeLayouts, "2006-01-02")
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return formatTime(value, dateTimeLayouts, "2006-01-02")
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return formatTime(stringLiteral(valueAST), dateTimeLayouts, "2006-01-02")
+	},
+})
+
+// dateTimeScalar is a RFC 3339 date-time, e.g. 2018-07-20T09:30:00Z.
+var dateTimeScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "DateTime",
+	Description: "A RFC 3339 date-time, e.g. 2018-07-20T09:30:00Z",
+	Serialize: func(value interface{}) interface{} {
+		return formatTime(value, dateTimeLayouts, time.RFC3339)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return parseTime(value, dateTimeLayouts)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return parseTime(stringLiteral(valueAST), dateTimeLayouts)
+	},
+})
+
+// timeScalar is a RFC 3339 partial-time, e.g. 09:30:00.
+var timeScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Time",
+	Description: "A RFC 3339 partial-time, e.g. 09:30:00",
+	Serialize: func(value interface{}) interface{} {
+		return formatTime(value, timeLayouts, "15:04:05")
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return formatTime(value, timeLayouts, "15:04:05")
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return formatTime(stringLiteral(valueAST), timeLayouts, "15:04:05")
+	},
+})
+
+// jsonScalar is any JSON value, stored encoded in database.
+var jsonScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "JSON",
+	Description: "Any JSON value",
+	Serialize:   decodeJSON,
+	ParseValue: func(value interface{}) interface{} {
+		return encodeJSON(value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return encodeJSON(literalValue(valueAST))
+	},
+})
+
+// geoJSONScalar is a GeoJSON geometry object, e.g. {"type": "Point", "coordinates": [2.35, 48.85]}.
+var geoJSONScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "GeoJSON",
+	Description: "A GeoJSON geometry object",
+	Serialize:   decodeJSON,
+	ParseValue: func(value interface{}) interface{} {
+		return encodeGeoJSON(value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return encodeGeoJSON(literalValue(valueAST))
+	},
+})
+
+// decimalScalar is an exact fixed-point number, as a string, e.g. "19.99".
+var decimalScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Decimal",
+	Description: "An exact fixed-point number, serialized as a string, e.g. \"19.99\"",
+	Serialize: func(value interface{}) interface{} {
+		return formatNumber(value, decimalPattern)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return formatNumber(value, decimalPattern)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return formatNumber(numberLiteral(valueAST), decimalPattern)
+	},
+})
+
+// bigIntScalar is an integer out of 32 bits range, as a string, e.g. "9007199254740993".
+var bigIntScalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "BigInt",
+	Description: "An integer of any size, serialized as a string, e.g. \"9007199254740993\"",
+	Serialize: func(value interface{}) interface{} {
+		return formatNumber(value, bigIntPattern)
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return formatNumber(value, bigIntPattern)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return formatNumber(numberLiteral(valueAST), bigIntPattern)
+	},
+})
+
+// base64Scalar is binary data, as a standard base64 string.
+var base64Scalar = graphql.NewScalar(graphql.ScalarConfig{
+	Name:        "Base64",
+	Description: "Binary data, serialized as a standard base64 string",
+	Serialize: func(value interface{}) interface{} {
+		switch v := value.(type) {
+		case []byte:
+			return base64.StdEncoding.EncodeToString(v)
+		case string:
+			return base64.StdEncoding.EncodeToString([]byte(v))
+		}
+		return nil
+	},
+	ParseValue: func(value interface{}) interface{} {
+		return decodeBase64(value)
+	},
+	ParseLiteral: func(valueAST ast.Value) interface{} {
+		return decodeBase64(stringLiteral(valueAST))
+	},
+})
+
+// decodeBase64 returns the bytes encoded by value, or nil when it is not base64.
+func decodeBase64(value interface{}) interface{} {
+	encoded, ok := value.(string)
+	if !ok {
+		return nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil
+	}
+	return decoded
+}
+
+// formatNumber returns value as a string, or nil when it does not match pattern.
+func formatNumber(value interface{}, pattern *regexp.Regexp) interface{} {
+	var number string
+	switch v := value.(type) {
+	case string:
+		number = v
+	case float32:
+		number = strconv.FormatFloat(float64(v), 'f', -1, 32)
+	case float64:
+		number = strconv.FormatFloat(v, 'f', -1, 64)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		number = fmt.Sprintf("%d", v)
+	default:
+		return nil
+	}
+	if !pattern.MatchString(number) {
+		return nil
+	}
+	return number
+}
+
+// numberLiteral returns the raw text of a number or string literal.
+func numberLiteral(valueAST ast.Value) interface{} {
+	switch v := valueAST.(type) {
+	case *ast.IntValue:
+		return v.Value
+	case *ast.FloatValue:
+		return v.Value
+	case *ast.StringValue:
+		return v.Value
+	}
+	return nil
+}
+
+// parseTime returns value as time.Time, or nil when it matches none of layouts.
+func parseTime(value interface{}, layouts []string) interface{} {
+	switch v := value.(type) {
+	case time.Time:
+		return v
+	case *time.Time:
+		if v == nil {
+			return nil
+		}
+		return *v
+	case string:
+		for _, layout := range layouts {
+			if t, err := time.Parse(layout, v); err == nil {
+				return t
+			}
+		}
+	}
+	return nil
+}
+
+// formatTime returns value formatted by layout, or nil when it cannot be parsed.
+func formatTime(value interface{}, layouts []string, layout string) interface{} {
+	t, ok := parseTime(value, layouts).(time.Time)
+	if !ok {
+		return nil
+	}
+	return t.Format(layout)
+}
+
+func stringLiteral(valueAST ast.Value) interface{} {
+	if v, ok := valueAST.(*ast.StringValue); ok {
+		return v.Value
+	}
+	return nil
+}
+
+// decodeJSON returns the value encoded in a JSON string, or value when it is not one.
+func decodeJSON(value interface{}) interface{} {
+	encoded, ok := value.(string)
+	if !ok {
+		return value
+	}
+	if len(encoded) == 0 {
+		return nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal([]byte(encoded), &decoded); err != nil {
+		return encoded
+	}
+	return decoded
+}
+
+// encodeGeoJSON encodes value, or returns nil when it is not a GeoJSON object.
+func encodeGeoJSON(value interface{}) interface{} {
+	geometry, ok := value.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if _, ok := geometry["type"].(string); !ok {
+		return nil
+	}
+	return encodeJSON(geometry)
+}
+
+func encodeJSON(value interface{}) interface{} {
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+	return string(encoded)
+}
+
+// literalValue converts a literal to the Go value a JSON decoder would return.
+func literalValue(valueAST ast.Value) interface{} {
+	switch v := valueAST.(type) {
+	case *ast.StringValue:
+		return v.Value
+	case *ast.BooleanValue:
+		return v.Value
+	case *ast.EnumValue:
+		return v.Value
+	case *ast.IntValue:
+		if i, err := strconv.ParseInt(v.Value, 10, 64); err == nil {
+			return i
+		}
+		return v.Value
+	case *ast.FloatValue:
+		if f, err := strconv.ParseFloat(v.Value, 64); err == nil {
+			return f
+		}
+		return v.Value
+	case *ast.ListValue:
+		values := make([]interface{}, 0, len(v.Values))
+		for _, value := range v.Values {
+			values = append(values, literalValue(value))
+		}
+		return values
+	case *ast.ObjectValue:
+		values := make(map[string]interface{})
+		for _, field := range v.Fields {
+			values[field.Name.Value] = literalValue(field.Value)
+		}
+		return values
+	}
+	return nil
+}
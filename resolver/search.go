@@ -0,0 +1,42 @@
+package resolver
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/suppayami/goql/schema"
+)
+
+// searchMatch returns MATCH ... AGAINST the search argument for every full-text index
+// of table joined by separator, appending the search to args. It is empty when the
+// search argument is not given.
+func searchMatch(
+	d dialect,
+	table *schema.SQLTableStruct,
+	wheres map[string]interface{},
+	separator string,
+	args []interface{},
+) (string, []interface{}, error) {
+	search, _ := wheres[schema.SearchArgumentName].(string)
+	if len(search) == 0 || len(table.FullTextIndexes) == 0 {
+		return "", args, nil
+	}
+	if len(d.match) == 0 {
+		return "", nil, fmt.Errorf("search is not supported by the database")
+	}
+	// the default mode is given by its enum name, anything but BOOLEAN is natural language
+	mode := schema.SearchModeEnum.Values[0]
+	if wheres[schema.SearchModeArgumentName] == schema.SearchModeEnum.Values[1] {
+		mode = schema.SearchModeEnum.Values[1]
+	}
+	matches := make([]string, 0, len(table.FullTextIndexes))
+	for _, index := range table.FullTextIndexes {
+		columns := make([]string, 0, len(index.Fields))
+		for _, field := range index.Fields {
+			columns = append(columns, d.quoteIdentifier(field))
+		}
+		args = append(args, search)
+		matches = append(matches, fmt.Sprintf(d.match, strings.Join(columns, ", "), d.placeholder(len(args)), mode))
+	}
+	return fmt.Sprintf("(%s)", strings.Join(matches, separator)), args, nil
+}
@@ -0,0 +1,73 @@
+package resolver
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func TestReadQuerySearch(t *testing.T) {
+	table := &schema.SQLTableStruct{
+		Name: "game",
+		Fields: []*schema.SQLFieldStruct{
+			&schema.SQLFieldStruct{Field: "game_id", Type: "int", IsPrimaryKey: true},
+			&schema.SQLFieldStruct{Field: "developer_id", Type: "int"},
+			&schema.SQLFieldStruct{Field: "name", Type: "varchar"},
+			&schema.SQLFieldStruct{Field: "summary", Type: "text"},
+		},
+		PrimaryKeys: []string{"game_id"},
+		FullTextIndexes: []*schema.SQLIndexStruct{
+			&schema.SQLIndexStruct{Name: "name", Fields: []string{"name"}},
+			&schema.SQLIndexStruct{Name: "summary", Fields: []string{"name", "summary"}},
+		},
+	}
+	tests := []struct {
+		wheres map[string]interface{}
+		sqlTxt string
+		args   []interface{}
+	}{
+		{
+			map[string]interface{}{
+				schema.SearchArgumentName:     "witcher",
+				schema.SearchModeArgumentName: schema.SearchModeEnum.Values[1],
+				"developerId":                 2,
+				"first":                       5,
+			},
+			"SELECT *, (MATCH (`name`) AGAINST (? IN BOOLEAN MODE) + MATCH (`name`, `summary`) AGAINST (? IN BOOLEAN MODE)) AS `search_score` " +
+				"FROM `game` WHERE `developer_id` = ? AND (MATCH (`name`) AGAINST (? IN BOOLEAN MODE) OR MATCH (`name`, `summary`) AGAINST (? IN BOOLEAN MODE)) " +
+				"ORDER BY `search_score` DESC, `game_id` ASC LIMIT ?",
+			[]interface{}{"witcher", "witcher", 2, "witcher", "witcher", 5},
+		},
+		{
+			map[string]interface{}{
+				schema.SearchArgumentName:     "witcher",
+				schema.SearchModeArgumentName: schema.SearchModeEnum.Values[0],
+				schema.OrderByArgumentName: []interface{}{
+					map[string]interface{}{schema.OrderByField: "name", schema.OrderByDirection: "ASC"},
+				},
+			},
+			"SELECT *, (MATCH (`name`) AGAINST (? IN NATURAL LANGUAGE MODE) + MATCH (`name`, `summary`) AGAINST (? IN NATURAL LANGUAGE MODE)) AS `search_score` " +
+				"FROM `game` WHERE (MATCH (`name`) AGAINST (? IN NATURAL LANGUAGE MODE) OR MATCH (`name`, `summary`) AGAINST (? IN NATURAL LANGUAGE MODE)) " +
+				"ORDER BY `name` ASC, `game_id` ASC",
+			[]interface{}{"witcher", "witcher", "witcher", "witcher"},
+		},
+	}
+	for _, test := range tests {
+		sqlTxt, args, err := readQuery(getDialect("mysql"), table, map[string]schema.GraphqlType{}, test.wheres)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if sqlTxt != test.sqlTxt {
+			t.Fatalf("Expected: \n%s\nGot:\n%s\n", test.sqlTxt, sqlTxt)
+		}
+		if !reflect.DeepEqual(args, test.args) {
+			t.Fatalf("Expected args %v, got %v", test.args, args)
+		}
+	}
+
+	_, _, err := readQuery(getDialect("sqlite3"), table, map[string]schema.GraphqlType{}, map[string]interface{}{schema.SearchArgumentName: "witcher"})
+	if err == nil {
+		t.Fatal("Expected search to be rejected by SQLite")
+	}
+}
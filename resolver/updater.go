@@ -0,0 +1,53 @@
+package resolver
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/suppayami/goql/schema"
+)
+
+func makeUpdater(
+	db *sql.DB,
+	d dialect,
+	table *schema.SQLTableStruct,
+	columns map[string]schema.GraphqlType,
+) func(map[string]interface{}, map[string]interface{}) error {
+	return func(keys map[string]interface{}, values map[string]interface{}) error {
+		if len(values) == 0 {
+			return nil
+		}
+		var sqlTxt string
+		setStatement := make([]string, 0, len(values))
+		args := make([]interface{}, 0, len(values)+len(keys))
+		for field, value := range values {
+			args = append(args, d.timeValue(value))
+			setStatement = append(setStatement, fmt.Sprintf(
+				"%s = %s",
+				d.quoteIdentifier(schema.GraphqlToSQLFieldName(field)),
+				d.bindValue(columns[field], d.placeholder(len(args))),
+			))
+		}
+		whereStatement, args := keyCondition(d, keys, args)
+		sqlTxt = fmt.Sprintf(
+			"UPDATE %s SET %s WHERE %s",
+			d.quoteTable(table),
+			strings.Join(setStatement, ", "),
+			whereStatement,
+		)
+		_, err := db.Exec(sqlTxt, args...)
+		return err
+	}
+}
+
+// keyCondition matches every primary key column in keys, appending their values to args.
+func keyCondition(d dialect, keys map[string]interface{}, args []interface{}) (string, []interface{}) {
+	keyStatement := make([]string, 0, len(keys))
+	for key, value := range keys {
+		key = schema.GraphqlToSQLFieldName(key)
+		args = append(args, d.timeValue(value))
+		keyStatement = append(keyStatement, fmt.Sprintf("%s = %s", d.quoteIdentifier(key), d.placeholder(len(args))))
+	}
+	return strings.Join(keyStatement, " AND "), args
+}
@@ -0,0 +1,121 @@
+package schema
+
+// Arguments and fields of aggregate queries.
+const (
+	GroupByArgumentName = "groupBy"
+
+	AggregateCount = "count"
+	AggregateSum   = "sum"
+	AggregateAvg   = "avg"
+	AggregateMin   = "min"
+	AggregateMax   = "max"
+	AggregateGroup = "group"
+)
+
+// sqlToGraphqlAggregateTypes returns the aggregate type of table with the types of
+// its fields: sums and averages of numeric columns as Decimal, or Float for floating
+// point columns, and minimums, maximums and grouped values of the orderable columns.
+func sqlToGraphqlAggregateTypes(mapper TypeMapper, sqlTable *SQLTableStruct) []GraphqlObjectType {
+	numbersType := GraphqlObjectType{
+		Name:   SQLToGraphqlAggregateNumbersName(sqlTable.Name),
+		Fields: []GraphqlField{},
+	}
+	valuesType := GraphqlObjectType{
+		Name:   SQLToGraphqlAggregateValuesName(sqlTable.Name),
+		Fields: []GraphqlField{},
+	}
+	for _, field := range sqlTable.Fields {
+		gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, field)
+		if gqlType == ScalarJSON || gqlType == ScalarGeoJSON || gqlType == ScalarBase64 {
+			continue
+		}
+		isKey := IsKey(*field) || field.IsPrimaryKey || field.IsForeignKey
+		if isKey {
+			gqlType = ScalarID
+		}
+		valuesType.Fields = append(valuesType.Fields, GraphqlField{
+			Name:       SQLToGraphqlFieldName(field.Field),
+			Type:       gqlType,
+			ObjectType: enumName,
+			Nullable:   true,
+		})
+		if isKey {
+			continue
+		}
+		switch gqlType {
+		case ScalarFloat:
+		case ScalarInt, ScalarBigInt, ScalarDecimal:
+			gqlType = ScalarDecimal
+		default:
+			continue
+		}
+		numbersType.Fields = append(numbersType.Fields, GraphqlField{
+			Name:     SQLToGraphqlFieldName(field.Field),
+			Type:     gqlType,
+			Nullable: true,
+		})
+	}
+
+	aggregateType := GraphqlObjectType{
+		Name: SQLToGraphqlAggregateName(sqlTable.Name),
+		Fields: []GraphqlField{
+			GraphqlField{
+				Name:     AggregateCount,
+				Type:     ScalarInt,
+				Nullable: false,
+			},
+		},
+	}
+	fieldTypes := []GraphqlObjectType{}
+	// object types without fields are invalid
+	if len(numbersType.Fields) > 0 {
+		for _, name := range []string{AggregateSum, AggregateAvg} {
+			aggregateType.Fields = append(aggregateType.Fields, GraphqlField{
+				Name:       name,
+				Type:       ObjectType,
+				ObjectType: numbersType.Name,
+				Nullable:   true,
+			})
+		}
+		fieldTypes = append(fieldTypes, numbersType)
+	}
+	if len(valuesType.Fields) > 0 {
+		for _, name := range []string{AggregateMin, AggregateMax, AggregateGroup} {
+			aggregateType.Fields = append(aggregateType.Fields, GraphqlField{
+				Name:       name,
+				Type:       ObjectType,
+				ObjectType: valuesType.Name,
+				Nullable:   true,
+			})
+		}
+		fieldTypes = append(fieldTypes, valuesType)
+	}
+	return append([]GraphqlObjectType{aggregateType}, fieldTypes...)
+}
+
+// sqlToGraphqlAggregateField returns the query field aggregating rows of table, once
+// per group of the groupBy columns if any.
+func sqlToGraphqlAggregateField(sqlTable *SQLTableStruct) GraphqlField {
+	return GraphqlField{
+		Name:       AggregateFieldName(sqlTable.Name),
+		Type:       ObjectType,
+		ObjectType: SQLToGraphqlAggregateName(sqlTable.Name),
+		Nullable:   false,
+		IsArray:    true,
+		Arguments: []GraphqlArgument{
+			GraphqlArgument{
+				Name:       WhereArgumentName,
+				Type:       InputType,
+				ObjectType: SQLToGraphqlFilterName(sqlTable.Name),
+				Nullable:   true,
+			},
+			GraphqlArgument{
+				Name:       GroupByArgumentName,
+				Type:       EnumType,
+				ObjectType: SQLToGraphqlOrderFieldName(sqlTable.Name),
+				Nullable:   false,
+				IsArray:    true,
+			},
+		},
+	}
+}
@@ -0,0 +1,125 @@
+package schema
+
+// Arguments and fields of Relay connections.
+const (
+	ConnectionFirst  = "first"
+	ConnectionAfter  = "after"
+	ConnectionLast   = "last"
+	ConnectionBefore = "before"
+
+	ConnectionEdges    = "edges"
+	ConnectionPageInfo = "pageInfo"
+	EdgeNode           = "node"
+	EdgeCursor         = "cursor"
+)
+
+// PageInfoType describes the page of a connection.
+var PageInfoType = GraphqlObjectType{
+	Name: "PageInfo",
+	Fields: []GraphqlField{
+		GraphqlField{
+			Name:     "hasNextPage",
+			Type:     ScalarBoolean,
+			Nullable: false,
+		},
+		GraphqlField{
+			Name:     "hasPreviousPage",
+			Type:     ScalarBoolean,
+			Nullable: false,
+		},
+		GraphqlField{
+			Name:     "startCursor",
+			Type:     ScalarString,
+			Nullable: true,
+		},
+		GraphqlField{
+			Name:     "endCursor",
+			Type:     ScalarString,
+			Nullable: true,
+		},
+	},
+}
+
+// sqlToGraphqlConnectionTypes returns the edge and connection types of table.
+func sqlToGraphqlConnectionTypes(sqlTable *SQLTableStruct) []GraphqlObjectType {
+	edgeType := GraphqlObjectType{
+		Name: SQLToGraphqlEdgeName(sqlTable.Name),
+		Fields: []GraphqlField{
+			GraphqlField{
+				Name:       EdgeNode,
+				Type:       ObjectType,
+				ObjectType: SQLToGraphqlObjectName(sqlTable.Name),
+				Nullable:   false,
+			},
+			GraphqlField{
+				Name:     EdgeCursor,
+				Type:     ScalarString,
+				Nullable: false,
+			},
+		},
+	}
+	connectionType := GraphqlObjectType{
+		Name: SQLToGraphqlConnectionName(sqlTable.Name),
+		Fields: []GraphqlField{
+			GraphqlField{
+				Name:       ConnectionEdges,
+				Type:       ObjectType,
+				ObjectType: edgeType.Name,
+				Nullable:   true,
+				IsArray:    true,
+			},
+			GraphqlField{
+				Name:       ConnectionPageInfo,
+				Type:       ObjectType,
+				ObjectType: PageInfoType.Name,
+				Nullable:   false,
+			},
+		},
+	}
+	return []GraphqlObjectType{edgeType, connectionType}
+}
+
+// sqlToGraphqlConnectionField returns the query field paginating rows of table by cursor.
+func sqlToGraphqlConnectionField(sqlTable *SQLTableStruct) GraphqlField {
+	return GraphqlField{
+		Name:       ConnectionFieldName(sqlTable.Name),
+		Type:       ObjectType,
+		ObjectType: SQLToGraphqlConnectionName(sqlTable.Name),
+		Nullable:   true,
+		Arguments: []GraphqlArgument{
+			GraphqlArgument{
+				Name:     ConnectionFirst,
+				Type:     ScalarInt,
+				Nullable: true,
+			},
+			GraphqlArgument{
+				Name:     ConnectionAfter,
+				Type:     ScalarString,
+				Nullable: true,
+			},
+			GraphqlArgument{
+				Name:     ConnectionLast,
+				Type:     ScalarInt,
+				Nullable: true,
+			},
+			GraphqlArgument{
+				Name:     ConnectionBefore,
+				Type:     ScalarString,
+				Nullable: true,
+			},
+			GraphqlArgument{
+				Name:       WhereArgumentName,
+				Type:       InputType,
+				ObjectType: SQLToGraphqlFilterName(sqlTable.Name),
+				Nullable:   true,
+			},
+			GraphqlArgument{
+				Name:       OrderByArgumentName,
+				Type:       InputType,
+				ObjectType: SQLToGraphqlOrderByName(sqlTable.Name),
+				Nullable:   false,
+				IsArray:    true,
+			},
+		},
+	}
+}
@@ -3,6 +3,7 @@ package schema
 import (
 	"fmt"
 	"strings"
+	"unicode"
 
 	"github.com/hungneox/stringutils"
 
@@ -48,6 +49,16 @@ func SQLToGraphqlCreateFieldName(fieldName string) string {
 	return fmt.Sprintf("create%s", stringutils.PascalCase(fieldName))
 }
 
+// SQLToGraphqlUpdateFieldName returns case for Update field in mutation
+func SQLToGraphqlUpdateFieldName(fieldName string) string {
+	return fmt.Sprintf("update%s", stringutils.PascalCase(fieldName))
+}
+
+// SQLToGraphqlDeleteFieldName returns case for Delete field in mutation
+func SQLToGraphqlDeleteFieldName(fieldName string) string {
+	return fmt.Sprintf("delete%s", stringutils.PascalCase(fieldName))
+}
+
 // GraphqlToSQLFieldName returns case for sql field
 func GraphqlToSQLFieldName(fieldName string) string {
 	return stringutils.SnakeCase(fieldName)
@@ -58,6 +69,42 @@ func SQLToGraphqlObjectName(tableName string) string {
 	return stringutils.PascalCase(tableName)
 }
 
+// SQLToGraphqlEnumName returns name of the enum type generated for a table field
+func SQLToGraphqlEnumName(tableName string, fieldName string) string {
+	return fmt.Sprintf("%s%s", stringutils.PascalCase(tableName), stringutils.PascalCase(fieldName))
+}
+
+// SQLToGraphqlEnumValue returns case for an enum value, replacing characters
+// not allowed in graphql names
+func SQLToGraphqlEnumValue(value string) string {
+	name := []rune(strings.ToUpper(value))
+	for i, r := range name {
+		if !(r == '_' || unicode.IsDigit(r) || (r >= 'A' && r <= 'Z')) {
+			name[i] = '_'
+		}
+	}
+	if len(name) == 0 || unicode.IsDigit(name[0]) {
+		return fmt.Sprintf("_%s", string(name))
+	}
+	return string(name)
+}
+
+// SQLToGraphqlEnumValues returns case for every value of an enum, suffixing values
+// whose case is taken by a previous one, e.g. 'a' and 'A' are A and A_2
+func SQLToGraphqlEnumValues(values []string) []string {
+	names := make([]string, 0, len(values))
+	taken := make(map[string]bool)
+	for _, value := range values {
+		name := SQLToGraphqlEnumValue(value)
+		for i := 2; taken[name]; i++ {
+			name = fmt.Sprintf("%s_%d", SQLToGraphqlEnumValue(value), i)
+		}
+		taken[name] = true
+		names = append(names, name)
+	}
+	return names
+}
+
 // GraphqlToSQLTableName returns case for sql table
 func GraphqlToSQLTableName(objectName string) string {
 	return stringutils.SnakeCase(objectName)
@@ -67,3 +114,85 @@ func GraphqlToSQLTableName(objectName string) string {
 func ArrayFieldName(fieldName string) string {
 	return inflection.Plural(fieldName)
 }
+
+// RelationshipFieldName returns graphql field name for a relationship
+func RelationshipFieldName(relationship SQLRelationshipStruct) string {
+	fieldName := SQLToGraphqlFieldName(relationship.Name)
+	if relationship.HasMany {
+		return ArrayFieldName(fieldName)
+	}
+	return fieldName
+}
+
+// ManyToManyFieldName returns graphql field name for a relationship reached
+// through a junction table
+func ManyToManyFieldName(relationship SQLRelationshipStruct) string {
+	return ArrayFieldName(SQLToGraphqlFieldName(relationship.Name))
+}
+
+// JSONPathArgumentName returns name of the list query argument filtering a JSON field
+func JSONPathArgumentName(fieldName string) string {
+	return fmt.Sprintf("%sPath", SQLToGraphqlFieldName(fieldName))
+}
+
+// SQLToGraphqlFilterName returns name of the input type filtering rows of a table
+func SQLToGraphqlFilterName(tableName string) string {
+	return fmt.Sprintf("%sFilter", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlOrderFieldName returns name of the enum of columns a table is ordered by
+func SQLToGraphqlOrderFieldName(tableName string) string {
+	return fmt.Sprintf("%sOrderField", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlOrderByName returns name of the input type ordering rows of a table
+func SQLToGraphqlOrderByName(tableName string) string {
+	return fmt.Sprintf("%sOrderBy", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlEdgeName returns name of the edge type of a table connection
+func SQLToGraphqlEdgeName(tableName string) string {
+	return fmt.Sprintf("%sEdge", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlConnectionName returns name of the connection type of a table
+func SQLToGraphqlConnectionName(tableName string) string {
+	return fmt.Sprintf("%sConnection", SQLToGraphqlObjectName(tableName))
+}
+
+// ConnectionFieldName returns name of the query field paginating a table by cursor
+func ConnectionFieldName(tableName string) string {
+	return fmt.Sprintf("%sConnection", ArrayFieldName(SQLToGraphqlFieldName(tableName)))
+}
+
+// SQLToGraphqlAggregateName returns name of the aggregate type of a table
+func SQLToGraphqlAggregateName(tableName string) string {
+	return fmt.Sprintf("%sAggregate", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlAggregateNumbersName returns name of the type of sums and averages of a table
+func SQLToGraphqlAggregateNumbersName(tableName string) string {
+	return fmt.Sprintf("%sAggregateNumbers", SQLToGraphqlObjectName(tableName))
+}
+
+// SQLToGraphqlAggregateValuesName returns name of the type of minimums, maximums and
+// grouped values of a table
+func SQLToGraphqlAggregateValuesName(tableName string) string {
+	return fmt.Sprintf("%sAggregateValues", SQLToGraphqlObjectName(tableName))
+}
+
+// AggregateFieldName returns name of the query field aggregating a table
+func AggregateFieldName(tableName string) string {
+	return fmt.Sprintf("%sAggregate", ArrayFieldName(SQLToGraphqlFieldName(tableName)))
+}
+
+// ComparisonInputName returns name of the input type comparing values of a type
+func ComparisonInputName(typeName string) string {
+	return fmt.Sprintf("%sComparison", typeName)
+}
+
+// NearArgumentName returns name of the list query argument filtering a spatial field
+// by distance
+func NearArgumentName(fieldName string) string {
+	return fmt.Sprintf("%sNear", SQLToGraphqlFieldName(fieldName))
+}
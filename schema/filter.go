@@ -0,0 +1,117 @@
+package schema
+
+// Filter combinators and comparison operators of the where argument.
+const (
+	FilterAnd = "and"
+	FilterOr  = "or"
+	FilterNot = "not"
+
+	OperatorEq     = "eq"
+	OperatorNe     = "ne"
+	OperatorGt     = "gt"
+	OperatorGte    = "gte"
+	OperatorLt     = "lt"
+	OperatorLte    = "lte"
+	OperatorIn     = "in"
+	OperatorLike   = "like"
+	OperatorIsNull = "isNull"
+)
+
+// WhereArgumentName is the list query argument filtering rows by a table filter.
+const WhereArgumentName = "where"
+
+// sqlToGraphqlFilterType returns the filter input of table, composing a comparison
+// of every comparable column, and the comparison inputs it uses.
+func sqlToGraphqlFilterType(mapper TypeMapper, sqlTable *SQLTableStruct) (GraphqlInputObjectType, []GraphqlInputObjectType) {
+	filterName := SQLToGraphqlFilterName(sqlTable.Name)
+	filterType := GraphqlInputObjectType{
+		Name:   filterName,
+		Fields: []GraphqlArgument{},
+	}
+	comparisons := []GraphqlInputObjectType{}
+	for _, field := range sqlTable.Fields {
+		fieldName := SQLToGraphqlFieldName(field.Field)
+		if fieldName == FilterAnd || fieldName == FilterOr || fieldName == FilterNot {
+			continue
+		}
+		gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, field)
+		if IsKey(*field) || field.IsPrimaryKey || field.IsForeignKey {
+			gqlType = ScalarID
+		}
+		comparison, ok := comparisonInput(gqlType, enumName)
+		if !ok {
+			continue
+		}
+		filterType.Fields = append(filterType.Fields, GraphqlArgument{
+			Name:       fieldName,
+			Type:       InputType,
+			ObjectType: comparison.Name,
+			Nullable:   true,
+		})
+		comparisons = append(comparisons, comparison)
+	}
+	filterType.Fields = append(filterType.Fields,
+		GraphqlArgument{
+			Name:       FilterAnd,
+			Type:       InputType,
+			ObjectType: filterName,
+			Nullable:   false,
+			IsArray:    true,
+		},
+		GraphqlArgument{
+			Name:       FilterOr,
+			Type:       InputType,
+			ObjectType: filterName,
+			Nullable:   false,
+			IsArray:    true,
+		},
+		GraphqlArgument{
+			Name:       FilterNot,
+			Type:       InputType,
+			ObjectType: filterName,
+			Nullable:   true,
+		},
+	)
+	return filterType, comparisons
+}
+
+// comparisonInput returns the input comparing values of gqlType, enumName names the
+// type of enums. Values which cannot be compared, e.g. JSON, have none.
+func comparisonInput(gqlType GraphqlType, enumName string) (GraphqlInputObjectType, bool) {
+	var operators []string
+	switch gqlType {
+	case ScalarJSON, ScalarGeoJSON, ScalarBase64:
+		return GraphqlInputObjectType{}, false
+	case ScalarBoolean:
+		operators = []string{OperatorEq, OperatorNe}
+	case EnumType:
+		operators = []string{OperatorEq, OperatorNe, OperatorIn}
+	case ScalarString:
+		operators = []string{OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorIn, OperatorLike}
+	default:
+		operators = []string{OperatorEq, OperatorNe, OperatorGt, OperatorGte, OperatorLt, OperatorLte, OperatorIn}
+	}
+	typeName := string(gqlType)
+	if gqlType == EnumType {
+		typeName = enumName
+	}
+	comparison := GraphqlInputObjectType{
+		Name:   ComparisonInputName(typeName),
+		Fields: []GraphqlArgument{},
+	}
+	for _, operator := range operators {
+		comparison.Fields = append(comparison.Fields, GraphqlArgument{
+			Name:       operator,
+			Type:       gqlType,
+			ObjectType: enumName,
+			Nullable:   operator != OperatorIn,
+			IsArray:    operator == OperatorIn,
+		})
+	}
+	comparison.Fields = append(comparison.Fields, GraphqlArgument{
+		Name:     OperatorIsNull,
+		Type:     ScalarBoolean,
+		Nullable: true,
+	})
+	return comparison, true
+}
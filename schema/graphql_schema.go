@@ -16,6 +16,20 @@ const (
 	ScalarBoolean GraphqlType = "Boolean"
 	ScalarID      GraphqlType = "ID"
 	ObjectType    GraphqlType = "ObjectType"
+	EnumType      GraphqlType = "EnumType"
+	InputType     GraphqlType = "InputType"
+)
+
+// Custom Scalar Types, declared in schema when used
+const (
+	ScalarDate     GraphqlType = "Date"
+	ScalarDateTime GraphqlType = "DateTime"
+	ScalarTime     GraphqlType = "Time"
+	ScalarJSON     GraphqlType = "JSON"
+	ScalarDecimal  GraphqlType = "Decimal"
+	ScalarBigInt   GraphqlType = "BigInt"
+	ScalarBase64   GraphqlType = "Base64"
+	ScalarGeoJSON  GraphqlType = "GeoJSON"
 )
 
 // Graphql Keyword
@@ -23,6 +37,8 @@ const (
 	KeywordType     string = "type"
 	KeywordInput    string = "input"
 	KeywordSchema   string = "schema"
+	KeywordScalar   string = "scalar"
+	KeywordEnum     string = "enum"
 	KeywordQuery    string = "Query"
 	KeywordMutation string = "Mutation"
 
@@ -32,26 +48,68 @@ const (
 	KeywordField             string = "%s: %s"
 	KeywordFieldArguments    string = "%s(%s): %s"
 	KeywordFieldDefaultValue string = "%s: %s = %s"
+	KeywordDescription       string = "\"\"\"%s\"\"\""
 )
 
+var customScalars = []GraphqlType{ScalarDate, ScalarDateTime, ScalarTime, ScalarJSON, ScalarDecimal, ScalarBigInt, ScalarBase64, ScalarGeoJSON}
+
+// JSONPathFilterInput is the input type filtering a JSON column by the value at a path.
+var JSONPathFilterInput = GraphqlInputObjectType{
+	Name: "JSONPathFilter",
+	Fields: []GraphqlArgument{
+		GraphqlArgument{
+			Name:     "path",
+			Type:     ScalarString,
+			Nullable: false,
+		},
+		GraphqlArgument{
+			Name:     "equals",
+			Type:     ScalarJSON,
+			Nullable: false,
+		},
+	},
+}
+
+// NearFilterInput is the input type filtering a spatial column by the distance to a
+// point, radius is in meters.
+var NearFilterInput = GraphqlInputObjectType{
+	Name: "NearFilter",
+	Fields: []GraphqlArgument{
+		GraphqlArgument{
+			Name:     "point",
+			Type:     ScalarGeoJSON,
+			Nullable: false,
+		},
+		GraphqlArgument{
+			Name:     "radius",
+			Type:     ScalarFloat,
+			Nullable: false,
+		},
+	},
+}
+
+// filterInputs are the input types declared when list query arguments use them.
+var filterInputs = []GraphqlInputObjectType{JSONPathFilterInput, NearFilterInput}
+
 // GraphqlSchemaBuilder pipes DBSchema into a barebone GraphqlSchema.
 type GraphqlSchemaBuilder interface{}
 
 // GraphqlField indicates a field.
-// ObjectType is an optional field, needed when Type is ObjectType.
+// ObjectType is an optional field, needed when Type is ObjectType, EnumType or InputType.
 type GraphqlField struct {
-	Name       string
-	Type       GraphqlType
-	ObjectType string
-	Nullable   bool
-	IsArray    bool
-	Arguments  []GraphqlArgument
+	Name        string
+	Type        GraphqlType
+	ObjectType  string
+	Nullable    bool
+	IsArray     bool
+	Arguments   []GraphqlArgument
+	Description string
 }
 
 func (gql GraphqlField) String() string {
 	var keywordFieldType, keywordArguments string
 
-	if gql.Type == ObjectType {
+	if gql.Type == ObjectType || gql.Type == EnumType || gql.Type == InputType {
 		keywordFieldType = gql.ObjectType
 	} else {
 		keywordFieldType = string(gql.Type)
@@ -79,18 +137,20 @@ func (gql GraphqlField) String() string {
 
 // GraphqlArgument is used for a field's argument.
 // DefaultValue is always a string and casted based on Type.
+// Nullable applies to the items of an array argument, the array itself is nullable.
 type GraphqlArgument struct {
 	Name         string
 	Type         GraphqlType
 	ObjectType   string
 	Nullable     bool
+	IsArray      bool
 	DefaultValue string
 }
 
 func (gql GraphqlArgument) String() string {
 	var keywordFieldType string
 
-	if gql.Type == ObjectType {
+	if gql.Type == ObjectType || gql.Type == EnumType || gql.Type == InputType {
 		keywordFieldType = gql.ObjectType
 	} else {
 		keywordFieldType = string(gql.Type)
@@ -108,28 +168,81 @@ func (gql GraphqlArgument) String() string {
 		keywordFieldType = fmt.Sprintf(KeywordNonNullableType, keywordFieldType)
 	}
 
+	if gql.IsArray {
+		keywordFieldType = fmt.Sprintf(KeywordArray, keywordFieldType)
+	}
+
 	return fmt.Sprintf(KeywordField, gql.Name, keywordFieldType)
 }
 
 // GraphqlObjectType describes an object type in Graphql.
 type GraphqlObjectType struct {
-	Name   string
-	Fields []GraphqlField
+	Name        string
+	Fields      []GraphqlField
+	Description string
 }
 
 func (gql GraphqlObjectType) String() string {
+	fields := make([]string, 0, len(gql.Fields))
+	for _, field := range gql.Fields {
+		fields = append(fields, fmt.Sprintf("%s\t%s", describe(field.Description, "\t"), field.String()))
+	}
+	return fmt.Sprintf("%s%s %s {\n%s\n}", describe(gql.Description, ""), KeywordType, gql.Name, strings.Join(fields, "\n"))
+}
+
+// describe returns description as a block string line, indented by indent.
+func describe(description string, indent string) string {
+	if len(description) == 0 {
+		return ""
+	}
+	description = strings.Replace(description, `"""`, `\"""`, -1)
+	if strings.HasSuffix(description, `"`) {
+		// keep a quote from merging with the closing ones
+		description = fmt.Sprintf("%s ", description)
+	}
+	return fmt.Sprintf("%s%s\n", indent, fmt.Sprintf(KeywordDescription, description))
+}
+
+// GraphqlInputObjectType describes an input object type in Graphql.
+type GraphqlInputObjectType struct {
+	Name   string
+	Fields []GraphqlArgument
+}
+
+func (gql GraphqlInputObjectType) String() string {
 	fields := make([]string, 0, len(gql.Fields))
 	for _, field := range gql.Fields {
 		fields = append(fields, fmt.Sprintf("\t%s", field.String()))
 	}
-	return fmt.Sprintf("%s %s {\n%s\n}", KeywordType, gql.Name, strings.Join(fields, "\n"))
+	return fmt.Sprintf("%s %s {\n%s\n}", KeywordInput, gql.Name, strings.Join(fields, "\n"))
+}
+
+// GraphqlEnumType describes an enum type in Graphql.
+// Values are kept as stored in database, see SQLToGraphqlEnumValues for their names.
+type GraphqlEnumType struct {
+	Name   string
+	Values []string
+}
+
+func (gql GraphqlEnumType) String() string {
+	values := make([]string, 0, len(gql.Values))
+	for _, name := range SQLToGraphqlEnumValues(gql.Values) {
+		values = append(values, fmt.Sprintf("\t%s", name))
+	}
+	return fmt.Sprintf("%s %s {\n%s\n}", KeywordEnum, gql.Name, strings.Join(values, "\n"))
 }
 
 // GraphqlSchema describes Graphql schema
+// ConnectionTypes and AggregateTypes are the object types of Relay connections and
+// aggregate queries, which are not tables.
 type GraphqlSchema struct {
-	QueryType    GraphqlObjectType
-	MutationType GraphqlObjectType
-	ObjectTypes  []GraphqlObjectType
+	QueryType       GraphqlObjectType
+	MutationType    GraphqlObjectType
+	ObjectTypes     []GraphqlObjectType
+	ConnectionTypes []GraphqlObjectType
+	AggregateTypes  []GraphqlObjectType
+	EnumTypes       []GraphqlEnumType
+	InputTypes      []GraphqlInputObjectType
 }
 
 func (gql GraphqlSchema) String() string {
@@ -139,10 +252,56 @@ func (gql GraphqlSchema) String() string {
 	for _, objectType := range gql.ObjectTypes {
 		objectTypes = append(objectTypes, objectType.String())
 	}
+	for _, connectionType := range gql.ConnectionTypes {
+		objectTypes = append(objectTypes, connectionType.String())
+	}
+	for _, aggregateType := range gql.AggregateTypes {
+		objectTypes = append(objectTypes, aggregateType.String())
+	}
+	for _, enumType := range gql.EnumTypes {
+		objectTypes = append(objectTypes, enumType.String())
+	}
+	for _, inputType := range gql.InputTypes {
+		objectTypes = append(objectTypes, inputType.String())
+	}
 	schemaTxt := fmt.Sprintf("%s {\n\tquery: Query\n\tmutation: Mutation\n}\n\n", KeywordSchema)
+	for _, scalar := range gql.CustomScalars() {
+		schemaTxt = fmt.Sprintf("%s%s %s\n", schemaTxt, KeywordScalar, scalar)
+	}
+	if len(gql.CustomScalars()) > 0 {
+		schemaTxt = fmt.Sprintf("%s\n", schemaTxt)
+	}
 	return fmt.Sprintf("%s%s", schemaTxt, strings.Join(objectTypes, "\n\n"))
 }
 
+// CustomScalars returns custom scalar types used by the schema, in declaration order.
+func (gql GraphqlSchema) CustomScalars() []GraphqlType {
+	used := make(map[GraphqlType]bool)
+	objectTypes := append([]GraphqlObjectType{gql.QueryType, gql.MutationType}, gql.ObjectTypes...)
+	objectTypes = append(objectTypes, gql.ConnectionTypes...)
+	objectTypes = append(objectTypes, gql.AggregateTypes...)
+	for _, objectType := range objectTypes {
+		for _, field := range objectType.Fields {
+			used[field.Type] = true
+			for _, arg := range field.Arguments {
+				used[arg.Type] = true
+			}
+		}
+	}
+	for _, inputType := range gql.InputTypes {
+		for _, field := range inputType.Fields {
+			used[field.Type] = true
+		}
+	}
+	scalars := []GraphqlType{}
+	for _, scalar := range customScalars {
+		if used[scalar] {
+			scalars = append(scalars, scalar)
+		}
+	}
+	return scalars
+}
+
 // SQLToGraphqlSchema converts SQL Schema to Graphql Schema
 func SQLToGraphqlSchema(sqlSchema SQLSchemaStruct) (GraphqlSchema, error) {
 	schema := GraphqlSchema{
@@ -154,53 +313,159 @@ func SQLToGraphqlSchema(sqlSchema SQLSchemaStruct) (GraphqlSchema, error) {
 			Name:   "Mutation",
 			Fields: []GraphqlField{},
 		},
-		ObjectTypes: []GraphqlObjectType{},
+		ObjectTypes:     []GraphqlObjectType{},
+		ConnectionTypes: []GraphqlObjectType{},
+		AggregateTypes:  []GraphqlObjectType{},
+		EnumTypes:       []GraphqlEnumType{},
+		InputTypes:      []GraphqlInputObjectType{},
 	}
 
+	mapper := sqlSchema.TypeMapper
+	if mapper == nil {
+		mapper = DefaultTypeMapper()
+	}
+	usedInputs := make(map[string]bool)
+	comparisons := []GraphqlInputObjectType{}
+	objectTables := make(map[string]*SQLTableStruct)
 	for _, sqlTable := range sqlSchema.Tables {
+		// e.g. public.user and audit.user
+		objectName := SQLToGraphqlObjectName(sqlTable.Name)
+		if other, ok := objectTables[objectName]; ok {
+			return schema, fmt.Errorf(
+				"tables %s and %s are both named %s in graphql",
+				other.QualifiedName(),
+				sqlTable.QualifiedName(),
+				objectName,
+			)
+		}
+		objectTables[objectName] = sqlTable
 		// if sqlTable.IsManyToMany {
 		// 	continue
 		// }
-		objectType := sqlToGraphqlObjectType(sqlTable)
-		queryFields := sqlToGraphqlQueryFields(sqlTable)
-		mutationFields := sqlToGraphqlMutationFields(sqlTable)
+		filterType, filterComparisons := sqlToGraphqlFilterType(mapper, sqlTable)
+		orderField, orderBy := sqlToGraphqlOrderTypes(mapper, sqlTable)
+		schema.InputTypes = append(schema.InputTypes, filterType, orderBy)
+		for _, comparison := range filterComparisons {
+			if !usedInputs[comparison.Name] {
+				usedInputs[comparison.Name] = true
+				comparisons = append(comparisons, comparison)
+			}
+		}
+		objectType := sqlToGraphqlObjectType(mapper, sqlTable)
+		queryFields := sqlToGraphqlQueryFields(mapper, sqlTable)
 		schema.ObjectTypes = append(schema.ObjectTypes, objectType)
+		schema.EnumTypes = append(schema.EnumTypes, sqlToGraphqlEnumTypes(mapper, sqlTable)...)
+		schema.EnumTypes = append(schema.EnumTypes, orderField)
 		for _, queryField := range queryFields {
 			schema.QueryType.Fields = append(schema.QueryType.Fields, queryField)
+			for _, arg := range queryField.Arguments {
+				if arg.Type == InputType {
+					usedInputs[arg.ObjectType] = true
+				}
+			}
+		}
+		schema.AggregateTypes = append(schema.AggregateTypes, sqlToGraphqlAggregateTypes(mapper, sqlTable)...)
+		schema.QueryType.Fields = append(schema.QueryType.Fields, sqlToGraphqlAggregateField(sqlTable))
+		if sqlSchema.Connections && len(sqlTable.PrimaryKeys) > 0 {
+			schema.ConnectionTypes = append(schema.ConnectionTypes, sqlToGraphqlConnectionTypes(sqlTable)...)
+			schema.QueryType.Fields = append(schema.QueryType.Fields, sqlToGraphqlConnectionField(sqlTable))
+		}
+		if sqlTable.IsView() {
+			continue
 		}
+		mutationFields := sqlToGraphqlMutationFields(mapper, sqlTable)
 		for _, mutationField := range mutationFields {
 			schema.MutationType.Fields = append(schema.MutationType.Fields, mutationField)
 		}
 	}
 
+	if len(sqlSchema.Tables) > 0 {
+		schema.EnumTypes = append(schema.EnumTypes, OrderDirectionEnum)
+	}
+	for _, sqlTable := range sqlSchema.Tables {
+		if len(sqlTable.FullTextIndexes) > 0 {
+			schema.EnumTypes = append(schema.EnumTypes, SearchModeEnum)
+			break
+		}
+	}
+	if len(schema.ConnectionTypes) > 0 {
+		schema.ConnectionTypes = append(schema.ConnectionTypes, PageInfoType)
+	}
+	schema.InputTypes = append(schema.InputTypes, comparisons...)
+	for _, inputType := range filterInputs {
+		if usedInputs[inputType.Name] {
+			schema.InputTypes = append(schema.InputTypes, inputType)
+		}
+	}
+
 	return schema, nil
 }
 
-// ConvertTypeSQLToGraphql converts SQL type to Graphql type
-// TODO: different db has different types
-func sqlToGraphqlType(sqlType string) GraphqlType {
-	typeLower := strings.ToLower(sqlType)
-	if strings.Contains(typeLower, "int") {
-		return ScalarInt
+// sqlToGraphqlFieldType converts the type of a table field, returning the name of
+// the generated type for enums.
+func sqlToGraphqlFieldType(mapper TypeMapper, sqlTable *SQLTableStruct, sqlField *SQLFieldStruct) (GraphqlType, string) {
+	gqlType := mapper.MapType(sqlTable, sqlField)
+	if gqlType == EnumType {
+		return gqlType, SQLToGraphqlEnumName(sqlTable.Name, sqlField.Field)
 	}
-	if strings.Contains(typeLower, "float") ||
-		strings.Contains(typeLower, "double") {
-		return ScalarFloat
+	return gqlType, ""
+}
+
+func sqlToGraphqlEnumTypes(mapper TypeMapper, sqlTable *SQLTableStruct) []GraphqlEnumType {
+	enumTypes := []GraphqlEnumType{}
+	for _, sqlField := range sqlTable.Fields {
+		if gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, sqlField); gqlType == EnumType {
+			enumTypes = append(enumTypes, GraphqlEnumType{
+				Name:   enumName,
+				Values: parseEnumValues(sqlField.Type),
+			})
+		}
+	}
+	return enumTypes
+}
+
+// parseEnumValues returns the values of an enum('a','b') column type.
+func parseEnumValues(sqlType string) []string {
+	values := []string{}
+	start := strings.Index(sqlType, "(")
+	end := strings.LastIndex(sqlType, ")")
+	if start < 0 || end < start {
+		return values
 	}
-	if strings.Contains(typeLower, "bit") {
-		return ScalarBoolean
+	var value []rune
+	quoted := false
+	runes := []rune(sqlType[start+1 : end])
+	for i := 0; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case !quoted && r == '\'':
+			quoted = true
+			value = []rune{}
+		case quoted && r == '\\' && i+1 < len(runes):
+			i++
+			value = append(value, runes[i])
+		case quoted && r == '\'' && i+1 < len(runes) && runes[i+1] == '\'':
+			i++
+			value = append(value, r)
+		case quoted && r == '\'':
+			quoted = false
+			values = append(values, string(value))
+		case quoted:
+			value = append(value, r)
+		}
 	}
-	return ScalarString
+	return values
 }
 
-func sqlToGraphqlObjectType(sqlTable *SQLTableStruct) GraphqlObjectType {
+func sqlToGraphqlObjectType(mapper TypeMapper, sqlTable *SQLTableStruct) GraphqlObjectType {
 	objectType := GraphqlObjectType{
-		Name:   SQLToGraphqlObjectName(sqlTable.Name),
-		Fields: []GraphqlField{},
+		Name:        SQLToGraphqlObjectName(sqlTable.Name),
+		Fields:      []GraphqlField{},
+		Description: sqlTable.Comment,
 	}
 
 	for _, sqlField := range sqlTable.Fields {
-		gqlType := sqlToGraphqlType(sqlField.Type)
+		gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, sqlField)
 		if sqlField.IsForeignKey {
 			continue
 		}
@@ -208,70 +473,113 @@ func sqlToGraphqlObjectType(sqlTable *SQLTableStruct) GraphqlObjectType {
 			gqlType = ScalarID
 		}
 		field := GraphqlField{
-			Name:     SQLToGraphqlFieldName(sqlField.Field),
-			Type:     gqlType,
-			IsArray:  false,
-			Nullable: sqlField.Null,
+			Name:        SQLToGraphqlFieldName(sqlField.Field),
+			Type:        gqlType,
+			ObjectType:  enumName,
+			IsArray:     false,
+			Nullable:    sqlField.Null,
+			Description: sqlField.Comment,
 		}
 		objectType.Fields = append(objectType.Fields, field)
 	}
+	if len(sqlTable.FullTextIndexes) > 0 {
+		objectType.Fields = append(objectType.Fields, searchScoreField())
+	}
 
 	for _, sqlRelationship := range sqlTable.Relationships {
 		if !sqlRelationship.Table.IsManyToMany {
 			field := GraphqlField{
-				Name:       SQLToGraphqlFieldName(sqlRelationship.Table.Name),
+				Name:       RelationshipFieldName(*sqlRelationship),
 				Type:       ObjectType,
 				ObjectType: SQLToGraphqlObjectName(sqlRelationship.Table.Name),
 				IsArray:    sqlRelationship.HasMany,
 				Nullable:   sqlRelationship.Null,
 			}
-			if field.IsArray {
-				field.Name = ArrayFieldName(field.Name)
-			}
 			objectType.Fields = append(objectType.Fields, field)
 			continue
 		}
-		// for _, manyToMany := range sqlRelationship.Table.Relationships {
-		// 	if manyToMany.Table == sqlTable {
-		// 		continue
-		// 	}
-		// 	field := GraphqlField{
-		// 		Name:       ArrayFieldName(SQLToGraphqlFieldName(manyToMany.Table.Name)),
-		// 		Type:       ObjectType,
-		// 		ObjectType: SQLToGraphqlObjectName(manyToMany.Table.Name),
-		// 		IsArray:    true,
-		// 		Nullable:   true,
-		// 	}
-		// 	objectType.Fields = append(objectType.Fields, field)
-		// }
+		for _, manyToMany := range sqlRelationship.Table.Relationships {
+			if manyToMany.HasMany || manyToMany.ForeignKey == sqlRelationship.LocalKey {
+				continue
+			}
+			field := GraphqlField{
+				Name:       ManyToManyFieldName(*manyToMany),
+				Type:       ObjectType,
+				ObjectType: SQLToGraphqlObjectName(manyToMany.Table.Name),
+				IsArray:    true,
+				Nullable:   true,
+				Arguments:  paginationArguments(),
+			}
+			objectType.Fields = append(objectType.Fields, field)
+		}
 	}
 
 	return objectType
 }
 
-func sqlToGraphqlQueryFields(sqlTable *SQLTableStruct) []GraphqlField {
+func paginationArguments() []GraphqlArgument {
+	return []GraphqlArgument{
+		GraphqlArgument{
+			Name:         "first",
+			Type:         ScalarInt,
+			Nullable:     true,
+			DefaultValue: "10",
+		},
+
+		GraphqlArgument{
+			Name:         "offset",
+			Type:         ScalarInt,
+			Nullable:     true,
+			DefaultValue: "0",
+		},
+	}
+}
+
+func sqlToGraphqlQueryFields(mapper TypeMapper, sqlTable *SQLTableStruct) []GraphqlField {
 	queryFields := []GraphqlField{}
+	listArgs := append(paginationArguments(),
+		GraphqlArgument{
+			Name:       WhereArgumentName,
+			Type:       InputType,
+			ObjectType: SQLToGraphqlFilterName(sqlTable.Name),
+			Nullable:   true,
+		},
+		GraphqlArgument{
+			Name:       OrderByArgumentName,
+			Type:       InputType,
+			ObjectType: SQLToGraphqlOrderByName(sqlTable.Name),
+			Nullable:   false,
+			IsArray:    true,
+		},
+	)
+	for _, field := range sqlTable.Fields {
+		switch mapper.MapType(sqlTable, field) {
+		case ScalarJSON:
+			listArgs = append(listArgs, GraphqlArgument{
+				Name:       JSONPathArgumentName(field.Field),
+				Type:       InputType,
+				ObjectType: JSONPathFilterInput.Name,
+				Nullable:   true,
+			})
+		case ScalarGeoJSON:
+			listArgs = append(listArgs, GraphqlArgument{
+				Name:       NearArgumentName(field.Field),
+				Type:       InputType,
+				ObjectType: NearFilterInput.Name,
+				Nullable:   true,
+			})
+		}
+	}
+	if len(sqlTable.FullTextIndexes) > 0 {
+		listArgs = append(listArgs, searchArguments()...)
+	}
 	queryFields = append(queryFields, GraphqlField{
 		Name:       ArrayFieldName(SQLToGraphqlFieldName(sqlTable.Name)),
 		Type:       ObjectType,
 		ObjectType: SQLToGraphqlObjectName(sqlTable.Name),
 		IsArray:    true,
 		Nullable:   true,
-		Arguments: []GraphqlArgument{
-			GraphqlArgument{
-				Name:         "first",
-				Type:         ScalarInt,
-				Nullable:     true,
-				DefaultValue: "10",
-			},
-
-			GraphqlArgument{
-				Name:         "offset",
-				Type:         ScalarInt,
-				Nullable:     true,
-				DefaultValue: "0",
-			},
-		},
+		Arguments:  listArgs,
 	})
 	singleQueryField := GraphqlField{
 		Name:       SQLToGraphqlFieldName(sqlTable.Name),
@@ -280,14 +588,8 @@ func sqlToGraphqlQueryFields(sqlTable *SQLTableStruct) []GraphqlField {
 		IsArray:    false,
 		Nullable:   true,
 	}
-	if !sqlTable.IsManyToMany {
-		singleQueryField.Arguments = []GraphqlArgument{
-			GraphqlArgument{
-				Name:     SQLToGraphqlFieldName(PrimaryKey(sqlTable.Name)),
-				Type:     ScalarID,
-				Nullable: false,
-			},
-		}
+	if len(sqlTable.PrimaryKeys) > 0 {
+		singleQueryField.Arguments = primaryKeyArguments(sqlTable)
 	} else {
 		args := make([]GraphqlArgument, 0, len(sqlTable.Relationships))
 		for _, relationship := range sqlTable.Relationships {
@@ -304,17 +606,20 @@ func sqlToGraphqlQueryFields(sqlTable *SQLTableStruct) []GraphqlField {
 	return queryFields
 }
 
-func sqlToGraphqlMutationFields(sqlTable *SQLTableStruct) []GraphqlField {
+func sqlToGraphqlMutationFields(mapper TypeMapper, sqlTable *SQLTableStruct) []GraphqlField {
 	mutationFields := []GraphqlField{}
 	args := make([]GraphqlArgument, 0, len(sqlTable.Fields)-1)
 	for _, field := range sqlTable.Fields {
-		if IsPrimaryKey(*sqlTable, *field) {
+		// keys set by database are left out, other keys must be given
+		if field.IsAutoIncrement || field.IsGenerated {
 			continue
 		}
+		gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, field)
 		args = append(args, GraphqlArgument{
-			Name:     SQLToGraphqlFieldName(field.Field),
-			Type:     sqlToGraphqlType(field.Type),
-			Nullable: false,
+			Name:       SQLToGraphqlFieldName(field.Field),
+			Type:       gqlType,
+			ObjectType: enumName,
+			Nullable:   field.IsOptional(),
 		})
 	}
 	createField := GraphqlField{
@@ -326,5 +631,64 @@ func sqlToGraphqlMutationFields(sqlTable *SQLTableStruct) []GraphqlField {
 		Arguments:  args,
 	}
 	mutationFields = append(mutationFields, createField)
+	if len(sqlTable.PrimaryKeys) == 0 {
+		return mutationFields
+	}
+
+	updateArgs := primaryKeyArguments(sqlTable)
+	for _, field := range sqlTable.Fields {
+		if isPrimaryKeyField(sqlTable, field.Field) || field.IsGenerated {
+			continue
+		}
+		gqlType, enumName := sqlToGraphqlFieldType(mapper, sqlTable, field)
+		updateArgs = append(updateArgs, GraphqlArgument{
+			Name:       SQLToGraphqlFieldName(field.Field),
+			Type:       gqlType,
+			ObjectType: enumName,
+			Nullable:   true,
+		})
+	}
+	if len(updateArgs) > len(sqlTable.PrimaryKeys) {
+		updateField := GraphqlField{
+			Name:       SQLToGraphqlUpdateFieldName(sqlTable.Name),
+			Type:       ObjectType,
+			ObjectType: SQLToGraphqlObjectName(sqlTable.Name),
+			IsArray:    false,
+			Nullable:   true,
+			Arguments:  updateArgs,
+		}
+		mutationFields = append(mutationFields, updateField)
+	}
+	deleteField := GraphqlField{
+		Name:       SQLToGraphqlDeleteFieldName(sqlTable.Name),
+		Type:       ObjectType,
+		ObjectType: SQLToGraphqlObjectName(sqlTable.Name),
+		IsArray:    false,
+		Nullable:   true,
+		Arguments:  primaryKeyArguments(sqlTable),
+	}
+	mutationFields = append(mutationFields, deleteField)
 	return mutationFields
 }
+
+// primaryKeyArguments returns a required ID argument for every primary key column.
+func primaryKeyArguments(sqlTable *SQLTableStruct) []GraphqlArgument {
+	args := make([]GraphqlArgument, 0, len(sqlTable.PrimaryKeys))
+	for _, key := range sqlTable.PrimaryKeys {
+		args = append(args, GraphqlArgument{
+			Name:     SQLToGraphqlFieldName(key),
+			Type:     ScalarID,
+			Nullable: false,
+		})
+	}
+	return args
+}
+
+func isPrimaryKeyField(sqlTable *SQLTableStruct, fieldName string) bool {
+	for _, key := range sqlTable.PrimaryKeys {
+		if strings.EqualFold(key, fieldName) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,8 @@ package schema_test
 
 import (
 	"fmt"
+	"regexp"
+	"strings"
 	"testing"
 
 	"github.com/suppayami/goql/schema"
@@ -57,3 +59,516 @@ func TestGraphqlObjectTypeStringer(t *testing.T) {
 		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlHumanType.String()))
 	}
 }
+
+func TestGraphqlSchemaDescriptions(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name:    "game",
+				Comment: "Games on sale",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)", Comment: `Title, e.g. "Dota 2"`},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "\"\"\"Games on sale\"\"\"\ntype Game {\n\tgameId: ID!\n\t\"\"\"Title, e.g. \"Dota 2\" \"\"\"\n\tname: String!\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+}
+
+func TestGraphqlSchemaCustomScalars(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "event",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "event_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "starts_at", Type: "datetime"},
+					&schema.SQLFieldStruct{Field: "day", Type: "date", Null: true},
+				},
+				PrimaryKeys: []string{"event_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "schema {\n\tquery: Query\n\tmutation: Mutation\n}\n\nscalar Date\nscalar DateTime\n\ntype Query {"
+	if !strings.HasPrefix(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected prefix: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+	expected = "type Event {\n\teventId: ID!\n\tstartsAt: DateTime!\n\tday: Date\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+}
+
+func TestGraphqlSchemaAggregateScalars(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "rating", Type: "int(11)"},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+		Connections: true,
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schemaTxt := gqlSchema.String()
+	declared := map[string]bool{"ID": true, "Int": true, "Float": true, "String": true, "Boolean": true}
+	for _, match := range regexp.MustCompile(`(?m)^(?:scalar|type|enum|input) (\w+)`).FindAllStringSubmatch(schemaTxt, -1) {
+		declared[match[1]] = true
+	}
+	for _, match := range regexp.MustCompile(`: \[?(\w+)`).FindAllStringSubmatch(schemaTxt, -1) {
+		if !declared[match[1]] {
+			t.Fatal(fmt.Sprintf("Expected %s to be declared, got:\n%s\n", match[1], schemaTxt))
+		}
+	}
+	expected := "type GameAggregateNumbers {\n\trating: Decimal\n}"
+	if !strings.Contains(schemaTxt, expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, schemaTxt))
+	}
+}
+
+func TestGraphqlSchemaNumericScalars(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "account",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "account_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "balance", Type: "decimal(12,2)"},
+					&schema.SQLFieldStruct{Field: "code", Type: "decimal(6,0)"},
+					&schema.SQLFieldStruct{Field: "visits", Type: "bigint(20) unsigned"},
+					&schema.SQLFieldStruct{Field: "logins", Type: "int(10) unsigned"},
+					&schema.SQLFieldStruct{Field: "level", Type: "tinyint(3) unsigned"},
+					&schema.SQLFieldStruct{Field: "rate", Type: "numeric"},
+				},
+				PrimaryKeys: []string{"account_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := "scalar Decimal\nscalar BigInt\n\ntype Query {"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+	expected = "type Account {\n\taccountId: ID!\n\tbalance: Decimal!\n\tcode: Int!\n\tvisits: BigInt!\n" +
+		"\tlogins: BigInt!\n\tlevel: Int!\n\trate: Decimal!\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+}
+
+func TestGraphqlSchemaTypeMappings(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "user",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "user_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "active", Type: "tinyint(1)"},
+					&schema.SQLFieldStruct{Field: "age", Type: "tinyint(4)"},
+					&schema.SQLFieldStruct{Field: "avatar", Type: "mediumblob", Null: true},
+					&schema.SQLFieldStruct{Field: "token", Type: "varbinary(16)"},
+				},
+				PrimaryKeys: []string{"user_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "type User {\n\tuserId: ID!\n\tactive: Boolean!\n\tage: Int!\n\tavatar: Base64\n\ttoken: Base64!\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+
+	mapping, err := schema.NewSQLTypeMapping(`^tinyint\(1\)`, "Int")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defaults := schema.TypeMappings
+	defer func() { schema.TypeMappings = defaults }()
+	schema.TypeMappings = append([]schema.SQLTypeMapping{mapping}, defaults...)
+	gqlSchema, err = schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected = "type User {\n\tuserId: ID!\n\tactive: Int!\n"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+	// classes keep their meaning, \D is not lowered to \d
+	mapping, err = schema.NewSQLTypeMapping(`^TINYINT\(\D`, "Int")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mapping.Pattern.MatchString("tinyint(1)") || !mapping.Pattern.MatchString("tinyint(x)") {
+		t.Fatal(fmt.Sprintf("Unexpected matches of %s", mapping.Pattern))
+	}
+	if _, err := schema.NewSQLTypeMapping("uuid", "Uuid"); err == nil {
+		t.Fatal("Expected unknown scalar to be rejected")
+	}
+}
+
+func TestGraphqlSchemaTypeMapper(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "user",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "user_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "uuid", Type: "varchar(36)"},
+					&schema.SQLFieldStruct{Field: "flags", Type: "int(11)"},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(36)"},
+				},
+				PrimaryKeys: []string{"user_id"},
+			},
+		},
+		TypeMapper: schema.ColumnTypeMapper{
+			Columns: map[string]schema.GraphqlType{
+				"uuid":       schema.ScalarID,
+				"user.flags": schema.ScalarBigInt,
+			},
+			Fallback: schema.PostgresSchemaBuilder{}.TypeMapper(),
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := "type User {\n\tuserId: ID!\n\tuuid: ID!\n\tflags: BigInt!\n\tname: String!\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+
+	mapper := schema.PostgresSchemaBuilder{}.TypeMapper()
+	table := sqlSchema.Tables[0]
+	for sqlType, expected := range map[string]schema.GraphqlType{
+		"interval":         schema.ScalarString,
+		"money":            schema.ScalarDecimal,
+		"point":            schema.ScalarString,
+		"integer":          schema.ScalarInt,
+		"double precision": schema.ScalarFloat,
+	} {
+		got := mapper.MapType(table, &schema.SQLFieldStruct{Field: "value", Type: sqlType})
+		if got != expected {
+			t.Fatalf("Expected %s to map to %s, got %s", sqlType, expected, got)
+		}
+	}
+}
+
+func TestGraphqlSchemaSpatialTypes(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "store",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "store_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "location", Type: "point"},
+					&schema.SQLFieldStruct{Field: "area", Type: "geometry(Polygon,4326)", Null: true},
+				},
+				PrimaryKeys: []string{"store_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"scalar GeoJSON\n",
+		"stores(first: Int = 10, offset: Int = 0, where: StoreFilter, orderBy: [StoreOrderBy!], locationNear: NearFilter, areaNear: NearFilter): [Store]",
+		"type Store {\n\tstoreId: ID!\n\tlocation: GeoJSON!\n\tarea: GeoJSON\n}",
+		"input NearFilter {\n\tpoint: GeoJSON!\n\tradius: Float!\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaOrderBy(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "release_date", Type: "date"},
+					&schema.SQLFieldStruct{Field: "metadata", Type: "json"},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"games(first: Int = 10, offset: Int = 0, where: GameFilter, orderBy: [GameOrderBy!], metadataPath: JSONPathFilter): [Game]",
+		"enum GameOrderField {\n\tGAME_ID\n\tRELEASE_DATE\n}",
+		"enum OrderDirection {\n\tASC\n\tDESC\n}",
+		"input GameOrderBy {\n\tfield: GameOrderField!\n\tdirection: OrderDirection = ASC\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaConnections(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)"},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+			&schema.SQLTableStruct{
+				Name: "game_summary",
+				Kind: schema.TableKindView,
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)"},
+				},
+			},
+		},
+		Connections: true,
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"gamesConnection(first: Int, after: String, last: Int, before: String, where: GameFilter, orderBy: [GameOrderBy!]): GameConnection",
+		"type GameEdge {\n\tnode: Game!\n\tcursor: String!\n}",
+		"type GameConnection {\n\tedges: [GameEdge]\n\tpageInfo: PageInfo!\n}",
+		"type PageInfo {\n\thasNextPage: Boolean!\n\thasPreviousPage: Boolean!\n\tstartCursor: String\n\tendCursor: String\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+	// views have no primary key to paginate by
+	if strings.Contains(gqlSchema.String(), "GameSummaryConnection") {
+		t.Fatal(fmt.Sprintf("Unexpected view connection:\n%s\n", gqlSchema.String()))
+	}
+}
+
+func TestGraphqlSchemaAggregates(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "price", Type: "decimal(10,2)"},
+					&schema.SQLFieldStruct{Field: "rating", Type: "float"},
+					&schema.SQLFieldStruct{Field: "release_date", Type: "date"},
+					&schema.SQLFieldStruct{Field: "metadata", Type: "json"},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"gamesAggregate(where: GameFilter, groupBy: [GameOrderField!]): [GameAggregate]!",
+		"type GameAggregate {\n\tcount: Int!\n\tsum: GameAggregateNumbers\n\tavg: GameAggregateNumbers\n\tmin: GameAggregateValues\n\tmax: GameAggregateValues\n\tgroup: GameAggregateValues\n}",
+		"type GameAggregateNumbers {\n\tprice: Decimal\n\trating: Float\n}",
+		"type GameAggregateValues {\n\tgameId: ID\n\tprice: Decimal\n\trating: Float\n\treleaseDate: Date\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaSearch(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "description", Type: "text"},
+				},
+				PrimaryKeys: []string{"game_id"},
+				FullTextIndexes: []*schema.SQLIndexStruct{
+					&schema.SQLIndexStruct{Name: "description", Fields: []string{"description"}},
+				},
+			},
+			&schema.SQLTableStruct{
+				Name: "developer",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "developer_id", Type: "int(11)", IsPrimaryKey: true},
+				},
+				PrimaryKeys: []string{"developer_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"games(first: Int = 10, offset: Int = 0, where: GameFilter, orderBy: [GameOrderBy!], search: String, searchMode: SearchMode = NATURAL_LANGUAGE): [Game]",
+		"developers(first: Int = 10, offset: Int = 0, where: DeveloperFilter, orderBy: [DeveloperOrderBy!]): [Developer]",
+		"\tdescription: String!\n\t\"\"\"Relevance of the row to the search argument, null when not searching.\"\"\"\n\tsearchScore: Float\n",
+		"enum SearchMode {\n\tNATURAL_LANGUAGE\n\tBOOLEAN\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaEnumCollisions(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "status", Type: "enum('early access','early_access','a','A')"},
+					&schema.SQLFieldStruct{Field: "Name", Type: "varchar(255)"},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)"},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"enum GameStatus {\n\tEARLY_ACCESS\n\tEARLY_ACCESS_2\n\tA\n\tA_2\n}",
+		"enum GameOrderField {\n\tGAME_ID\n\tSTATUS\n\tNAME\n\tNAME_2\n}",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaDuplicateTables(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name:   "user",
+				Schema: "public",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "user_id", Type: "integer", IsPrimaryKey: true},
+				},
+				PrimaryKeys: []string{"user_id"},
+			},
+			&schema.SQLTableStruct{
+				Name:   "user",
+				Schema: "audit",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "user_id", Type: "integer", IsPrimaryKey: true},
+				},
+				PrimaryKeys: []string{"user_id"},
+			},
+		},
+	}
+	if _, err := schema.SQLToGraphqlSchema(sqlSchema); err == nil {
+		t.Fatal("Expected tables named alike in different schemas to be rejected")
+	}
+}
+
+func TestGraphqlSchemaCreateKeys(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "country",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "country_id", Type: "char(2)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)"},
+				},
+				PrimaryKeys: []string{"country_id"},
+			},
+			&schema.SQLTableStruct{
+				Name: "city",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "id", Type: "int(11)", IsPrimaryKey: true, IsAutoIncrement: true},
+					&schema.SQLFieldStruct{Field: "name", Type: "varchar(255)"},
+				},
+				PrimaryKeys: []string{"id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, expected := range []string{
+		"createCountry(countryId: String!, name: String!): Country",
+		"createCity(name: String!): City",
+	} {
+		if !strings.Contains(gqlSchema.String(), expected) {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+		}
+	}
+}
+
+func TestGraphqlSchemaEnumTypes(t *testing.T) {
+	sqlSchema := schema.SQLSchemaStruct{
+		Tables: []*schema.SQLTableStruct{
+			&schema.SQLTableStruct{
+				Name: "game",
+				Fields: []*schema.SQLFieldStruct{
+					&schema.SQLFieldStruct{Field: "game_id", Type: "int(11)", IsPrimaryKey: true},
+					&schema.SQLFieldStruct{Field: "status", Type: `enum('released','early access','it''s 2\'s')`},
+				},
+				PrimaryKeys: []string{"game_id"},
+			},
+		},
+	}
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// followed by the order enums
+	if len(gqlSchema.EnumTypes) != 3 || len(gqlSchema.EnumTypes[0].Values) != 3 ||
+		gqlSchema.EnumTypes[0].Values[2] != "it's 2's" {
+		t.Fatal(fmt.Sprintf("Unexpected enum types: %+v", gqlSchema.EnumTypes))
+	}
+	expected := "type GameAggregateValues {\n\tgameId: ID\n\tstatus: GameStatus\n}\n\nenum GameStatus {\n\tRELEASED\n\tEARLY_ACCESS\n\tIT_S_2_S\n}"
+	if !strings.Contains(gqlSchema.String(), expected) {
+		t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, gqlSchema.String()))
+	}
+}
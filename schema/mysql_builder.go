@@ -6,7 +6,7 @@ import (
 	"strings"
 )
 
-// MySQLSchemaBuilder implements SQLSchemaBuilder
+// MySQLSchemaBuilder implements SQLSchemaBuilder, SQLForeignKeyBuilder and SQLFullTextBuilder
 type MySQLSchemaBuilder struct{}
 
 type mySQLField struct {
@@ -16,26 +16,47 @@ type mySQLField struct {
 	Key     string
 	Default sql.NullString
 	Extra   string
+	Comment string
+}
+
+// Driver implementation
+func (builder MySQLSchemaBuilder) Driver() string {
+	return "mysql"
+}
+
+// TypeMapper implementation
+func (builder MySQLSchemaBuilder) TypeMapper() TypeMapper {
+	return DefaultTypeMapper()
 }
 
 // QueryTables implementation
 func (builder MySQLSchemaBuilder) QueryTables(db *sql.DB) ([]*SQLTableStruct, error) {
 	tables := []*SQLTableStruct{}
-	rows, err := db.Query("SHOW TABLES")
+	rows, err := db.Query(`
+		SELECT TABLE_NAME, TABLE_TYPE, TABLE_COMMENT
+		FROM information_schema.TABLES
+		WHERE TABLE_SCHEMA = DATABASE()
+		ORDER BY TABLE_NAME`)
 	if err != nil {
 		return tables, err
 	}
 	defer rows.Close()
 	for rows.Next() {
-		var tableName string
-		if err := rows.Scan(&tableName); err != nil {
+		var tableName, tableType, tableComment string
+		if err := rows.Scan(&tableName, &tableType, &tableComment); err != nil {
 			return tables, err
 		}
 		table := SQLTableStruct{
 			Name:          tableName,
+			Kind:          SQLTableKind(tableType),
+			Comment:       tableComment,
 			Fields:        []*SQLFieldStruct{},
 			Relationships: []*SQLRelationshipStruct{},
 		}
+		if table.IsView() {
+			// MySQL comments every view as VIEW
+			table.Comment = ""
+		}
 		tables = append(tables, &table)
 	}
 	if err := rows.Err(); err != nil {
@@ -47,7 +68,11 @@ func (builder MySQLSchemaBuilder) QueryTables(db *sql.DB) ([]*SQLTableStruct, er
 // QueryFields implementation
 func (builder MySQLSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*SQLFieldStruct, error) {
 	fields := []*SQLFieldStruct{}
-	rows, err := db.Query(fmt.Sprintf("DESCRIBE %s", tableName))
+	rows, err := db.Query(`
+		SELECT COLUMN_NAME, COLUMN_TYPE, IS_NULLABLE, COLUMN_KEY, COLUMN_DEFAULT, EXTRA, COLUMN_COMMENT
+		FROM information_schema.COLUMNS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ?
+		ORDER BY ORDINAL_POSITION`, tableName)
 	if err != nil {
 		return fields, err
 	}
@@ -61,6 +86,7 @@ func (builder MySQLSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*
 			&fieldStruct.Key,
 			&fieldStruct.Default,
 			&fieldStruct.Extra,
+			&fieldStruct.Comment,
 		); err != nil {
 			fmt.Println(rows)
 			return fields, err
@@ -70,7 +96,15 @@ func (builder MySQLSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*
 			Null:         strings.EqualFold(fieldStruct.Null, "yes"),
 			Type:         fieldStruct.Type,
 			IsPrimaryKey: strings.EqualFold(fieldStruct.Key, "PRI"),
+			Comment:      fieldStruct.Comment,
+			Default:      fieldStruct.Default.String,
+			HasDefault:   fieldStruct.Default.Valid,
 		}
+		extra := strings.ToLower(fieldStruct.Extra)
+		field.IsAutoIncrement = strings.Contains(extra, "auto_increment")
+		// DEFAULT_GENERATED only flags an expression default
+		field.IsGenerated = strings.Contains(extra, "virtual generated") ||
+			strings.Contains(extra, "stored generated")
 		fields = append(fields, &field)
 	}
 	if err := rows.Err(); err != nil {
@@ -78,3 +112,61 @@ func (builder MySQLSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*
 	}
 	return fields, nil
 }
+
+// QueryForeignKeys implementation
+func (builder MySQLSchemaBuilder) QueryForeignKeys(db *sql.DB, tableName string) ([]*SQLForeignKeyStruct, error) {
+	keys := []*SQLForeignKeyStruct{}
+	rows, err := db.Query(`
+		SELECT k.COLUMN_NAME, k.REFERENCED_TABLE_NAME, k.REFERENCED_COLUMN_NAME
+		FROM information_schema.KEY_COLUMN_USAGE k
+		JOIN information_schema.REFERENTIAL_CONSTRAINTS r
+			ON r.CONSTRAINT_SCHEMA = k.CONSTRAINT_SCHEMA
+			AND r.CONSTRAINT_NAME = k.CONSTRAINT_NAME
+			AND r.TABLE_NAME = k.TABLE_NAME
+		WHERE k.TABLE_SCHEMA = DATABASE() AND k.TABLE_NAME = ?
+		ORDER BY k.CONSTRAINT_NAME, k.ORDINAL_POSITION`, tableName)
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var key SQLForeignKeyStruct
+		if err := rows.Scan(&key.Field, &key.ReferencedTable, &key.ReferencedField); err != nil {
+			return keys, err
+		}
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return keys, err
+	}
+	return keys, nil
+}
+
+// QueryFullTextIndexes implementation
+func (builder MySQLSchemaBuilder) QueryFullTextIndexes(db *sql.DB, tableName string) ([]*SQLIndexStruct, error) {
+	indexes := []*SQLIndexStruct{}
+	rows, err := db.Query(`
+		SELECT INDEX_NAME, COLUMN_NAME
+		FROM information_schema.STATISTICS
+		WHERE TABLE_SCHEMA = DATABASE() AND TABLE_NAME = ? AND INDEX_TYPE = 'FULLTEXT'
+		ORDER BY INDEX_NAME, SEQ_IN_INDEX`, tableName)
+	if err != nil {
+		return indexes, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var indexName, columnName string
+		if err := rows.Scan(&indexName, &columnName); err != nil {
+			return indexes, err
+		}
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != indexName {
+			indexes = append(indexes, &SQLIndexStruct{Name: indexName, Fields: []string{}})
+		}
+		index := indexes[len(indexes)-1]
+		index.Fields = append(index.Fields, columnName)
+	}
+	if err := rows.Err(); err != nil {
+		return indexes, err
+	}
+	return indexes, nil
+}
@@ -0,0 +1,49 @@
+package schema
+
+// Arguments and input fields ordering list queries.
+const (
+	OrderByArgumentName = "orderBy"
+	OrderByField        = "field"
+	OrderByDirection    = "direction"
+)
+
+// OrderDirectionEnum is the direction of an ordering, values are SQL keywords.
+var OrderDirectionEnum = GraphqlEnumType{
+	Name:   "OrderDirection",
+	Values: []string{"ASC", "DESC"},
+}
+
+// sqlToGraphqlOrderTypes returns the enum of columns rows of table can be ordered by,
+// and the input ordering by one of them. Values of the enum are column names.
+func sqlToGraphqlOrderTypes(mapper TypeMapper, sqlTable *SQLTableStruct) (GraphqlEnumType, GraphqlInputObjectType) {
+	fieldEnum := GraphqlEnumType{
+		Name:   SQLToGraphqlOrderFieldName(sqlTable.Name),
+		Values: []string{},
+	}
+	for _, field := range sqlTable.Fields {
+		gqlType, _ := sqlToGraphqlFieldType(mapper, sqlTable, field)
+		if gqlType == ScalarJSON || gqlType == ScalarGeoJSON || gqlType == ScalarBase64 {
+			continue
+		}
+		fieldEnum.Values = append(fieldEnum.Values, field.Field)
+	}
+	orderBy := GraphqlInputObjectType{
+		Name: SQLToGraphqlOrderByName(sqlTable.Name),
+		Fields: []GraphqlArgument{
+			GraphqlArgument{
+				Name:       OrderByField,
+				Type:       EnumType,
+				ObjectType: fieldEnum.Name,
+				Nullable:   false,
+			},
+			GraphqlArgument{
+				Name:         OrderByDirection,
+				Type:         EnumType,
+				ObjectType:   OrderDirectionEnum.Name,
+				Nullable:     true,
+				DefaultValue: OrderDirectionEnum.Values[0],
+			},
+		},
+	}
+	return fieldEnum, orderBy
+}
@@ -0,0 +1,139 @@
+package schema
+
+import (
+	"database/sql"
+	"regexp"
+	"strings"
+)
+
+const (
+	postgresDefaultSchema = "public"
+)
+
+// postgresTypeMappings are checked before TypeMappings for PostgreSQL types which
+// would be mistaken by the defaults.
+var postgresTypeMappings = []SQLTypeMapping{
+	{regexp.MustCompile(`^interval`), ScalarString},
+	{regexp.MustCompile(`^money`), ScalarDecimal},
+	{regexp.MustCompile(`^(point|line|lseg|box|path|polygon|circle)$`), ScalarString},
+}
+
+// PostgresSchemaBuilder implements SQLSchemaBuilder
+type PostgresSchemaBuilder struct{}
+
+type postgresField struct {
+	Field     string
+	Type      string
+	Null      string
+	Default   sql.NullString
+	IsPrimary bool
+	Comment   sql.NullString
+	Identity  string
+	Generated string
+}
+
+// Driver implementation
+func (builder PostgresSchemaBuilder) Driver() string {
+	return "postgres"
+}
+
+// TypeMapper implementation
+func (builder PostgresSchemaBuilder) TypeMapper() TypeMapper {
+	return TableTypeMapper{
+		Mappings: postgresTypeMappings,
+		Fallback: DefaultTypeMapper(),
+	}
+}
+
+// QueryTables implementation
+func (builder PostgresSchemaBuilder) QueryTables(db *sql.DB) ([]*SQLTableStruct, error) {
+	tables := []*SQLTableStruct{}
+	rows, err := db.Query(`
+		SELECT table_schema, table_name, table_type,
+			obj_description(format('%I.%I', table_schema, table_name)::regclass, 'pg_class')
+		FROM information_schema.tables
+		WHERE table_type IN ('BASE TABLE', 'VIEW')
+		AND table_schema NOT IN ('pg_catalog', 'information_schema')
+		ORDER BY table_schema, table_name`)
+	if err != nil {
+		return tables, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableSchema, tableName, tableType string
+		var tableComment sql.NullString
+		if err := rows.Scan(&tableSchema, &tableName, &tableType, &tableComment); err != nil {
+			return tables, err
+		}
+		table := SQLTableStruct{
+			Name:          tableName,
+			Schema:        tableSchema,
+			Kind:          SQLTableKind(tableType),
+			Comment:       tableComment.String,
+			Fields:        []*SQLFieldStruct{},
+			Relationships: []*SQLRelationshipStruct{},
+		}
+		tables = append(tables, &table)
+	}
+	if err := rows.Err(); err != nil {
+		return tables, err
+	}
+	return tables, nil
+}
+
+// QueryFields implementation, tableName may be qualified with its schema.
+func (builder PostgresSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*SQLFieldStruct, error) {
+	fields := []*SQLFieldStruct{}
+	tableSchema := postgresDefaultSchema
+	if i := strings.Index(tableName, "."); i >= 0 {
+		tableSchema, tableName = tableName[:i], tableName[i+1:]
+	}
+	rows, err := db.Query(`
+		SELECT c.column_name, format_type(a.atttypid, a.atttypmod), c.is_nullable,
+			c.column_default, COALESCE(i.indisprimary, false), col_description(t.oid, a.attnum),
+			c.is_identity, c.is_generated
+		FROM information_schema.columns c
+		JOIN pg_catalog.pg_namespace n ON n.nspname = c.table_schema
+		JOIN pg_catalog.pg_class t ON t.relnamespace = n.oid AND t.relname = c.table_name
+		JOIN pg_catalog.pg_attribute a ON a.attrelid = t.oid AND a.attname = c.column_name
+		LEFT JOIN pg_catalog.pg_index i
+			ON i.indrelid = t.oid AND i.indisprimary AND a.attnum = ANY(i.indkey)
+		WHERE c.table_schema = $1 AND c.table_name = $2
+		ORDER BY c.ordinal_position`, tableSchema, tableName)
+	if err != nil {
+		return fields, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fieldStruct postgresField
+		if err := rows.Scan(
+			&fieldStruct.Field,
+			&fieldStruct.Type,
+			&fieldStruct.Null,
+			&fieldStruct.Default,
+			&fieldStruct.IsPrimary,
+			&fieldStruct.Comment,
+			&fieldStruct.Identity,
+			&fieldStruct.Generated,
+		); err != nil {
+			return fields, err
+		}
+		field := SQLFieldStruct{
+			Field:        fieldStruct.Field,
+			Null:         strings.EqualFold(fieldStruct.Null, "yes"),
+			Type:         fieldStruct.Type,
+			IsPrimaryKey: fieldStruct.IsPrimary,
+			Comment:      fieldStruct.Comment.String,
+			Default:      fieldStruct.Default.String,
+			HasDefault:   fieldStruct.Default.Valid,
+			IsAutoIncrement: strings.EqualFold(fieldStruct.Identity, "yes") ||
+				strings.HasPrefix(fieldStruct.Default.String, "nextval("),
+			IsGenerated: strings.EqualFold(fieldStruct.Generated, "always"),
+		}
+		fields = append(fields, &field)
+	}
+	if err := rows.Err(); err != nil {
+		return fields, err
+	}
+	return fields, nil
+}
@@ -0,0 +1,42 @@
+package schema
+
+// Arguments and field of full-text search.
+const (
+	SearchArgumentName     = "search"
+	SearchModeArgumentName = "searchMode"
+	SearchScoreField       = "searchScore"
+)
+
+// SearchModeEnum is the mode of a full-text search, values are SQL keywords.
+var SearchModeEnum = GraphqlEnumType{
+	Name:   "SearchMode",
+	Values: []string{"NATURAL LANGUAGE", "BOOLEAN"},
+}
+
+// searchArguments returns the arguments searching the full-text indexes of a table.
+func searchArguments() []GraphqlArgument {
+	return []GraphqlArgument{
+		GraphqlArgument{
+			Name:     SearchArgumentName,
+			Type:     ScalarString,
+			Nullable: true,
+		},
+		GraphqlArgument{
+			Name:         SearchModeArgumentName,
+			Type:         EnumType,
+			ObjectType:   SearchModeEnum.Name,
+			Nullable:     true,
+			DefaultValue: SQLToGraphqlEnumValue(SearchModeEnum.Values[0]),
+		},
+	}
+}
+
+// searchScoreField returns the field of the relevance of a row to the search argument.
+func searchScoreField() GraphqlField {
+	return GraphqlField{
+		Name:        SearchScoreField,
+		Type:        ScalarFloat,
+		Nullable:    true,
+		Description: "Relevance of the row to the search argument, null when not searching.",
+	}
+}
@@ -9,34 +9,123 @@ import (
 // SQLSchemaBuilder queries the database schema and builds it into a readable struct,
 // allows GraphqlSchemaBuilder build a barebone Graphql schema from database structure.
 type SQLSchemaBuilder interface {
+	// Driver returns the database/sql driver name the builder is made for.
+	Driver() string
+
+	// TypeMapper returns the TypeMapper converting the types of the database.
+	TypeMapper() TypeMapper
+
 	// QueryTables should only returns a slice of SQLTableStruct without the Fields.
 	// The fields will be appended in the main builder function.
+	// Views should be returned with TableKindView.
 	QueryTables(db *sql.DB) ([]*SQLTableStruct, error)
 
 	// QueryFields should map the table description from database to a slice of
-	// SQLFieldStruct.
+	// SQLFieldStruct. tableName is qualified with its schema when the table has one.
 	QueryFields(db *sql.DB, tableName string) ([]*SQLFieldStruct, error)
 }
 
+// SQLForeignKeyBuilder is optionally implemented by a SQLSchemaBuilder which is able
+// to read foreign keys declared in the database. Relationships are inferred by naming
+// convention when the builder does not implement it or the table declares none.
+type SQLForeignKeyBuilder interface {
+	// QueryForeignKeys should return the foreign keys declared on the table.
+	// tableName is qualified with its schema when the table has one.
+	QueryForeignKeys(db *sql.DB, tableName string) ([]*SQLForeignKeyStruct, error)
+}
+
+// SQLFullTextBuilder is optionally implemented by a SQLSchemaBuilder which is able
+// to read full-text indexes, tables without any cannot be searched.
+type SQLFullTextBuilder interface {
+	// QueryFullTextIndexes should return the full-text indexes of the table.
+	// tableName is qualified with its schema when the table has one.
+	QueryFullTextIndexes(db *sql.DB, tableName string) ([]*SQLIndexStruct, error)
+}
+
 // SQLFieldStruct describes a field in table of database.
+// Comment is the documentation of the column in database, if any.
+// Default is the default expression of the column, only meaningful when HasDefault.
+// Generated columns are computed by database and cannot be written.
 type SQLFieldStruct struct {
-	Field        string
-	Type         string
-	Null         bool
-	IsPrimaryKey bool
-	IsForeignKey bool
+	Field           string
+	Type            string
+	Null            bool
+	IsPrimaryKey    bool
+	IsForeignKey    bool
+	Comment         string
+	Default         string
+	HasDefault      bool
+	IsAutoIncrement bool
+	IsGenerated     bool
+}
+
+// IsOptional check if the field may be left out when a row is created
+func (field SQLFieldStruct) IsOptional() bool {
+	return field.Null || field.HasDefault || field.IsAutoIncrement
 }
 
+// SQLTableKind is the kind of a table in database.
+type SQLTableKind string
+
+// Table kinds, views are read-only.
+const (
+	TableKindBase SQLTableKind = "BASE TABLE"
+	TableKindView SQLTableKind = "VIEW"
+)
+
 // SQLTableStruct describes a table in database.
+// Schema is optional, used by databases which namespace tables into schemas.
+// PrimaryKeys lists every column of the primary key, in table order.
+// Comment is the documentation of the table in database, if any.
+// FullTextIndexes are searched by the search argument of list queries.
 type SQLTableStruct struct {
-	Name          string
-	Fields        []*SQLFieldStruct
-	Relationships []*SQLRelationshipStruct
-	IsManyToMany  bool
+	Name            string
+	Schema          string
+	Kind            SQLTableKind
+	Comment         string
+	Fields          []*SQLFieldStruct
+	PrimaryKeys     []string
+	Relationships   []*SQLRelationshipStruct
+	IsManyToMany    bool
+	FullTextIndexes []*SQLIndexStruct
+}
+
+// QualifiedName returns table name prefixed with its schema, if any
+func (table SQLTableStruct) QualifiedName() string {
+	if len(table.Schema) == 0 {
+		return table.Name
+	}
+	return fmt.Sprintf("%s.%s", table.Schema, table.Name)
+}
+
+// IsView check if the table is a read-only view
+func (table SQLTableStruct) IsView() bool {
+	return table.Kind == TableKindView
+}
+
+// FindField returns the field named fieldName, or nil when table has none
+func (table SQLTableStruct) FindField(fieldName string) *SQLFieldStruct {
+	for _, field := range table.Fields {
+		if strings.EqualFold(field.Field, fieldName) {
+			return field
+		}
+	}
+	return nil
+}
+
+// PrimaryKeyField returns the primary key column of table, falling back to
+// the naming convention when the database does not declare one.
+func (table SQLTableStruct) PrimaryKeyField() string {
+	if len(table.PrimaryKeys) > 0 {
+		return table.PrimaryKeys[0]
+	}
+	return PrimaryKey(table.Name)
 }
 
 // SQLRelationshipStruct describes a relationship between tables.
+// Name is the relationship name in SQL case, used for the graphql field.
 type SQLRelationshipStruct struct {
+	Name       string
 	Table      *SQLTableStruct
 	ForeignKey string
 	LocalKey   string
@@ -44,9 +133,28 @@ type SQLRelationshipStruct struct {
 	HasMany    bool
 }
 
+// SQLForeignKeyStruct describes a foreign key declared in database.
+// ReferencedField may be empty, meaning the primary key of ReferencedTable.
+type SQLForeignKeyStruct struct {
+	Field           string
+	ReferencedTable string
+	ReferencedField string
+}
+
+// SQLIndexStruct describes an index declared in database, Fields are in index order.
+type SQLIndexStruct struct {
+	Name   string
+	Fields []string
+}
+
 // SQLSchemaStruct describes database schema.
+// TypeMapper converts field types to Graphql, DefaultTypeMapper is used when nil.
+// Connections adds a Relay connection query field for tables with a primary key.
 type SQLSchemaStruct struct {
-	Tables []*SQLTableStruct
+	Driver      string
+	Tables      []*SQLTableStruct
+	TypeMapper  TypeMapper
+	Connections bool
 }
 
 // GetBuilder switches SQL driver to a SQLSchemaBuilder
@@ -54,6 +162,10 @@ func GetBuilder(driver string) SQLSchemaBuilder {
 	switch driver {
 	case "mysql":
 		return MySQLSchemaBuilder{}
+	case "postgres":
+		return PostgresSchemaBuilder{}
+	case "sqlite3":
+		return SQLiteSchemaBuilder{}
 	default:
 		panic(fmt.Sprintf("%s driver is not supported", driver))
 	}
@@ -62,25 +174,72 @@ func GetBuilder(driver string) SQLSchemaBuilder {
 // BuildSQLSchema builds a SQL Schema from given connecting database
 func BuildSQLSchema(db *sql.DB, builder SQLSchemaBuilder) (SQLSchemaStruct, error) {
 	schema := SQLSchemaStruct{
-		Tables: []*SQLTableStruct{},
+		Driver:     builder.Driver(),
+		Tables:     []*SQLTableStruct{},
+		TypeMapper: builder.TypeMapper(),
 	}
 	tables, err := builder.QueryTables(db)
 	if err != nil {
 		return schema, err
 	}
+	foreignKeys := make(map[*SQLTableStruct][]*SQLForeignKeyStruct)
 	for _, table := range tables {
-		fields, err := builder.QueryFields(db, table.Name)
+		fields, err := builder.QueryFields(db, table.QualifiedName())
 		if err != nil {
 			return schema, err
 		}
 		table.Fields = fields
-		setupRelationships(tables, table)
+		table.PrimaryKeys = primaryKeys(table)
+		if fkBuilder, ok := builder.(SQLForeignKeyBuilder); ok {
+			keys, err := fkBuilder.QueryForeignKeys(db, table.QualifiedName())
+			if err != nil {
+				return schema, err
+			}
+			foreignKeys[table] = keys
+		}
+		if ftBuilder, ok := builder.(SQLFullTextBuilder); ok {
+			indexes, err := ftBuilder.QueryFullTextIndexes(db, table.QualifiedName())
+			if err != nil {
+				return schema, err
+			}
+			table.FullTextIndexes = indexes
+		}
+	}
+	for _, table := range tables {
+		// tables declaring no foreign key are related by naming convention
+		if len(foreignKeys[table]) > 0 && !table.IsView() {
+			setupForeignKeys(tables, table, foreignKeys[table])
+		} else {
+			setupRelationships(tables, table)
+		}
 		table.IsManyToMany = isManyToManyTable(table)
 		schema.Tables = append(schema.Tables, table)
 	}
 	return schema, nil
 }
 
+func setupForeignKeys(tableList []*SQLTableStruct, table *SQLTableStruct, keys []*SQLForeignKeyStruct) {
+	for _, key := range keys {
+		field := table.FindField(key.Field)
+		if field == nil {
+			continue
+		}
+		for j := range tableList {
+			foundTable := tableList[j]
+			if !strings.EqualFold(foundTable.Name, key.ReferencedTable) &&
+				!strings.EqualFold(foundTable.QualifiedName(), key.ReferencedTable) {
+				continue
+			}
+			referencedField := key.ReferencedField
+			if len(referencedField) == 0 {
+				referencedField = foundTable.PrimaryKeyField()
+			}
+			addRelationship(table, field, foundTable, referencedField)
+			break
+		}
+	}
+}
+
 func setupRelationships(tableList []*SQLTableStruct, table *SQLTableStruct) {
 	for i := range table.Fields {
 		field := table.Fields[i]
@@ -96,29 +255,59 @@ func setupRelationships(tableList []*SQLTableStruct, table *SQLTableStruct) {
 			if !strings.EqualFold(foundTable.Name, modelName) {
 				continue
 			}
-			relForeign := SQLRelationshipStruct{
-				Table:      foundTable,
-				ForeignKey: field.Field,
-				LocalKey:   PrimaryKey(foundTable.Name),
-				Null:       field.Null,
-			}
-			table.Relationships = append(table.Relationships, &relForeign)
-			relLocal := SQLRelationshipStruct{
-				Table:      table,
-				ForeignKey: PrimaryKey(foundTable.Name),
-				LocalKey:   field.Field,
-				HasMany:    true,
-				Null:       true,
-			}
-			foundTable.Relationships = append(foundTable.Relationships, &relLocal)
-			field.IsForeignKey = true
+			addRelationship(table, field, foundTable, PrimaryKey(foundTable.Name))
+		}
+	}
+}
+
+// primaryKeys returns primary key columns declared in database, or the column
+// named by convention when there is none.
+func primaryKeys(table *SQLTableStruct) []string {
+	keys := []string{}
+	for _, field := range table.Fields {
+		if field.IsPrimaryKey {
+			keys = append(keys, field.Field)
 		}
 	}
+	if len(keys) == 0 {
+		if field := table.FindField(PrimaryKey(table.Name)); field != nil {
+			keys = append(keys, field.Field)
+		}
+	}
+	return keys
+}
+
+// addRelationship links field of table to referencedField of foundTable, both ways.
+// The relationship is named after field, the reverse one after table, prefixed with
+// field when it does not follow the naming convention.
+func addRelationship(table *SQLTableStruct, field *SQLFieldStruct, foundTable *SQLTableStruct, referencedField string) {
+	localName := table.Name
+	if !strings.EqualFold(field.Field, PrimaryKey(foundTable.Name)) {
+		localName = fmt.Sprintf("%s_%s", TableName(field.Field), table.Name)
+	}
+	relForeign := SQLRelationshipStruct{
+		Name:       TableName(field.Field),
+		Table:      foundTable,
+		ForeignKey: field.Field,
+		LocalKey:   referencedField,
+		Null:       field.Null,
+	}
+	table.Relationships = append(table.Relationships, &relForeign)
+	relLocal := SQLRelationshipStruct{
+		Name:       localName,
+		Table:      table,
+		ForeignKey: referencedField,
+		LocalKey:   field.Field,
+		HasMany:    true,
+		Null:       true,
+	}
+	foundTable.Relationships = append(foundTable.Relationships, &relLocal)
+	field.IsForeignKey = true
 }
 
 // TODO: Many-to-many relationship should be checked by some conventions
 func isManyToManyTable(table *SQLTableStruct) bool {
-	if len(table.Relationships) < 2 {
+	if table.IsView() || len(table.Relationships) < 2 {
 		return false
 	}
 	for _, field := range table.Fields {
@@ -0,0 +1,158 @@
+package schema
+
+import (
+	"database/sql"
+	"strings"
+)
+
+// SQLiteSchemaBuilder implements SQLSchemaBuilder and SQLForeignKeyBuilder
+type SQLiteSchemaBuilder struct{}
+
+type sqliteField struct {
+	CID     int
+	Field   string
+	Type    string
+	NotNull bool
+	Default sql.NullString
+	PK      int
+	Hidden  int
+}
+
+type sqliteForeignKey struct {
+	ID       int
+	Seq      int
+	Table    string
+	From     string
+	To       sql.NullString
+	OnUpdate string
+	OnDelete string
+	Match    string
+}
+
+// Driver implementation
+func (builder SQLiteSchemaBuilder) Driver() string {
+	return "sqlite3"
+}
+
+// TypeMapper implementation
+func (builder SQLiteSchemaBuilder) TypeMapper() TypeMapper {
+	return DefaultTypeMapper()
+}
+
+// QueryTables implementation
+func (builder SQLiteSchemaBuilder) QueryTables(db *sql.DB) ([]*SQLTableStruct, error) {
+	tables := []*SQLTableStruct{}
+	rows, err := db.Query("SELECT name, type FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite_%' ORDER BY name")
+	if err != nil {
+		return tables, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var tableName, tableType string
+		if err := rows.Scan(&tableName, &tableType); err != nil {
+			return tables, err
+		}
+		kind := TableKindBase
+		if tableType == "view" {
+			kind = TableKindView
+		}
+		table := SQLTableStruct{
+			Name:          tableName,
+			Kind:          kind,
+			Fields:        []*SQLFieldStruct{},
+			Relationships: []*SQLRelationshipStruct{},
+		}
+		tables = append(tables, &table)
+	}
+	if err := rows.Err(); err != nil {
+		return tables, err
+	}
+	return tables, nil
+}
+
+// QueryFields implementation
+func (builder SQLiteSchemaBuilder) QueryFields(db *sql.DB, tableName string) ([]*SQLFieldStruct, error) {
+	fields := []*SQLFieldStruct{}
+	rows, err := db.Query("SELECT * FROM pragma_table_xinfo(?)", tableName)
+	if err != nil {
+		return fields, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var fieldStruct sqliteField
+		if err := rows.Scan(
+			&fieldStruct.CID,
+			&fieldStruct.Field,
+			&fieldStruct.Type,
+			&fieldStruct.NotNull,
+			&fieldStruct.Default,
+			&fieldStruct.PK,
+			&fieldStruct.Hidden,
+		); err != nil {
+			return fields, err
+		}
+		if fieldStruct.Hidden == 1 {
+			// hidden column of a virtual table
+			continue
+		}
+		field := SQLFieldStruct{
+			Field:        fieldStruct.Field,
+			Null:         !fieldStruct.NotNull && fieldStruct.PK == 0,
+			Type:         fieldStruct.Type,
+			IsPrimaryKey: fieldStruct.PK > 0,
+			Default:      fieldStruct.Default.String,
+			HasDefault:   fieldStruct.Default.Valid,
+			IsGenerated:  fieldStruct.Hidden > 1,
+		}
+		fields = append(fields, &field)
+	}
+	if err := rows.Err(); err != nil {
+		return fields, err
+	}
+	// a single INTEGER PRIMARY KEY is an alias of the auto-incremented rowid
+	primaryKeys := []*SQLFieldStruct{}
+	for _, field := range fields {
+		if field.IsPrimaryKey {
+			primaryKeys = append(primaryKeys, field)
+		}
+	}
+	if len(primaryKeys) == 1 && strings.EqualFold(primaryKeys[0].Type, "integer") {
+		primaryKeys[0].IsAutoIncrement = true
+	}
+	return fields, nil
+}
+
+// QueryForeignKeys implementation
+func (builder SQLiteSchemaBuilder) QueryForeignKeys(db *sql.DB, tableName string) ([]*SQLForeignKeyStruct, error) {
+	keys := []*SQLForeignKeyStruct{}
+	rows, err := db.Query("SELECT * FROM pragma_foreign_key_list(?)", tableName)
+	if err != nil {
+		return keys, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var keyStruct sqliteForeignKey
+		if err := rows.Scan(
+			&keyStruct.ID,
+			&keyStruct.Seq,
+			&keyStruct.Table,
+			&keyStruct.From,
+			&keyStruct.To,
+			&keyStruct.OnUpdate,
+			&keyStruct.OnDelete,
+			&keyStruct.Match,
+		); err != nil {
+			return keys, err
+		}
+		key := SQLForeignKeyStruct{
+			Field:           keyStruct.From,
+			ReferencedTable: keyStruct.Table,
+			ReferencedField: keyStruct.To.String,
+		}
+		keys = append(keys, &key)
+	}
+	if err := rows.Err(); err != nil {
+		return keys, err
+	}
+	return keys, nil
+}
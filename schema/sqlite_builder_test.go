@@ -0,0 +1,217 @@
+package schema_test
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	_ "github.com/mattn/go-sqlite3"
+	"github.com/suppayami/goql/schema"
+)
+
+const sqliteGamesFixture = `
+CREATE TABLE developer (
+	developer_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT
+);
+CREATE TABLE game (
+	game_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT NOT NULL,
+	rating REAL,
+	developer_id INTEGER
+);
+CREATE TABLE genre (
+	genre_id INTEGER PRIMARY KEY AUTOINCREMENT,
+	name TEXT
+);
+CREATE TABLE game_genre (
+	game_id INTEGER NOT NULL,
+	genre_id INTEGER NOT NULL,
+	PRIMARY KEY (game_id, genre_id)
+);`
+
+func openSQLite(t *testing.T, fixture string) *sql.DB {
+	db, err := sql.Open("sqlite3", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(fixture); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func findTable(t *testing.T, sqlSchema schema.SQLSchemaStruct, name string) *schema.SQLTableStruct {
+	for _, table := range sqlSchema.Tables {
+		if table.Name == name {
+			return table
+		}
+	}
+	t.Fatalf("Table %s is missing", name)
+	return nil
+}
+
+func TestSQLiteBuilderFields(t *testing.T) {
+	db := openSQLite(t, sqliteGamesFixture)
+	defer db.Close()
+
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sqlSchema.Driver != "sqlite3" || len(sqlSchema.Tables) != 4 {
+		t.Fatalf("Expected 4 sqlite3 tables, got %d %s tables", len(sqlSchema.Tables), sqlSchema.Driver)
+	}
+
+	game := findTable(t, sqlSchema, "game")
+	expected := []schema.SQLFieldStruct{
+		{Field: "game_id", Type: "INTEGER", IsPrimaryKey: true, IsAutoIncrement: true},
+		{Field: "name", Type: "TEXT"},
+		{Field: "rating", Type: "REAL", Null: true},
+		{Field: "developer_id", Type: "INTEGER", Null: true, IsForeignKey: true},
+	}
+	if len(game.Fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d", len(expected), len(game.Fields))
+	}
+	for i, field := range game.Fields {
+		if *field != expected[i] {
+			t.Fatalf("Expected: %+v\nGot: %+v", expected[i], *field)
+		}
+	}
+	gameGenre := findTable(t, sqlSchema, "game_genre")
+	if !gameGenre.IsManyToMany {
+		t.Fatal("Expected game_genre to be many-to-many")
+	}
+	if fmt.Sprint(gameGenre.PrimaryKeys) != "[game_id genre_id]" {
+		t.Fatalf("Expected composite primary key, got %v", gameGenre.PrimaryKeys)
+	}
+}
+
+func TestSQLiteBuilderForeignKeys(t *testing.T) {
+	db := openSQLite(t, `
+		CREATE TABLE user (user_id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE post (
+			post_id INTEGER PRIMARY KEY,
+			author INTEGER REFERENCES user,
+			category_id INTEGER
+		);`)
+	defer db.Close()
+
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	post := findTable(t, sqlSchema, "post")
+	if len(post.Relationships) != 1 {
+		t.Fatalf("Expected 1 relationship, got %d", len(post.Relationships))
+	}
+	rel := post.Relationships[0]
+	if rel.Table.Name != "user" || rel.ForeignKey != "author" || rel.LocalKey != "user_id" || rel.HasMany {
+		t.Fatalf("Unexpected relationship: %+v", *rel)
+	}
+	if schema.RelationshipFieldName(*rel) != "author" {
+		t.Fatalf("Expected author field, got %s", schema.RelationshipFieldName(*rel))
+	}
+	user := findTable(t, sqlSchema, "user")
+	if len(user.Relationships) != 1 || !user.Relationships[0].HasMany {
+		t.Fatal("Expected user to have many posts")
+	}
+	if schema.RelationshipFieldName(*user.Relationships[0]) != "authorPosts" {
+		t.Fatalf("Expected authorPosts field, got %s", schema.RelationshipFieldName(*user.Relationships[0]))
+	}
+}
+
+func TestSQLiteBuilderMixedRelationships(t *testing.T) {
+	db := openSQLite(t, `
+		CREATE TABLE user (user_id INTEGER PRIMARY KEY, name TEXT);
+		CREATE TABLE post (post_id INTEGER PRIMARY KEY, author INTEGER REFERENCES user);
+		CREATE TABLE comment (comment_id INTEGER PRIMARY KEY, post_id INTEGER, body TEXT);`)
+	defer db.Close()
+
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// comment declares no foreign key, post_id still relates it by convention
+	comment := findTable(t, sqlSchema, "comment")
+	if len(comment.Relationships) != 1 || comment.Relationships[0].Table.Name != "post" {
+		t.Fatal(fmt.Sprintf("Unexpected relationships: %+v", comment.Relationships))
+	}
+	post := findTable(t, sqlSchema, "post")
+	if len(post.Relationships) != 2 {
+		t.Fatal(fmt.Sprintf("Unexpected relationships: %+v", post.Relationships))
+	}
+}
+
+func TestSQLiteBuilderQuotedNames(t *testing.T) {
+	db := openSQLite(t, `
+		CREATE TABLE "order" (order_id INTEGER PRIMARY KEY, total REAL);
+		CREATE TABLE "order line" (
+			line_id INTEGER PRIMARY KEY,
+			"order" INTEGER REFERENCES "order"
+		);`)
+	defer db.Close()
+
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	line := findTable(t, sqlSchema, "order line")
+	if len(line.Fields) != 2 || line.Fields[1].Field != "order" {
+		t.Fatal(fmt.Sprintf("Unexpected fields: %+v", line.Fields))
+	}
+	if len(line.Relationships) != 1 || line.Relationships[0].Table.Name != "order" {
+		t.Fatal(fmt.Sprintf("Unexpected relationships: %+v", line.Relationships))
+	}
+}
+
+func TestSQLiteBuilderDefaults(t *testing.T) {
+	db := openSQLite(t, `
+		CREATE TABLE item (
+			item_id INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			price REAL NOT NULL DEFAULT 0,
+			note TEXT,
+			label TEXT GENERATED ALWAYS AS (name || ' ' || price) VIRTUAL
+		);`)
+	defer db.Close()
+
+	sqlSchema, err := schema.BuildSQLSchema(db, schema.GetBuilder("sqlite3"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item := findTable(t, sqlSchema, "item")
+	expected := []schema.SQLFieldStruct{
+		{Field: "item_id", Type: "INTEGER", IsPrimaryKey: true, IsAutoIncrement: true},
+		{Field: "name", Type: "TEXT"},
+		{Field: "price", Type: "REAL", Default: "0", HasDefault: true},
+		{Field: "note", Type: "TEXT", Null: true},
+		{Field: "label", Type: "TEXT", Null: true, IsGenerated: true},
+	}
+	if len(item.Fields) != len(expected) {
+		t.Fatalf("Expected %d fields, got %d", len(expected), len(item.Fields))
+	}
+	for i, field := range item.Fields {
+		if *field != expected[i] {
+			t.Fatalf("Expected: %+v\nGot: %+v", expected[i], *field)
+		}
+	}
+
+	gqlSchema, err := schema.SQLToGraphqlSchema(sqlSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, field := range gqlSchema.MutationType.Fields {
+		if field.Name != "createItem" {
+			continue
+		}
+		expected := "createItem(name: String!, price: Float, note: String): Item"
+		if field.String() != expected {
+			t.Fatal(fmt.Sprintf("Expected: \n%s\nGot:\n%s\n", expected, field.String()))
+		}
+	}
+}
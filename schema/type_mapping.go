@@ -0,0 +1,115 @@
+package schema
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// SQLTypeMapping maps SQL types matching Pattern to a Graphql type.
+type SQLTypeMapping struct {
+	Pattern *regexp.Regexp
+	Type    GraphqlType
+}
+
+// TypeMappings is the ordered table converting SQL types to Graphql types used by
+// DefaultTypeMapper, the first mapping matching a lower-cased SQL type wins.
+var TypeMappings = []SQLTypeMapping{
+	{regexp.MustCompile(`^enum\(`), EnumType},
+	{regexp.MustCompile(`json`), ScalarJSON},
+	{regexp.MustCompile(`^(geometry|geography|point|linestring|polygon|multipoint|multilinestring|multipolygon|geometrycollection|geomcollection)`), ScalarGeoJSON},
+	{regexp.MustCompile(`^(tinyint\(1\)|bit\(1\)|bit$|bool)`), ScalarBoolean},
+	{regexp.MustCompile(`blob|binary|bytea`), ScalarBase64},
+	// fixed-point integers fitting 32 bits
+	{regexp.MustCompile(`(decimal|numeric)\(\s*[1-9]\s*(,\s*0\s*)?\)`), ScalarInt},
+	{regexp.MustCompile(`(decimal|numeric)\(\s*\d+\s*(,\s*0\s*)?\)`), ScalarBigInt},
+	{regexp.MustCompile(`decimal|numeric`), ScalarDecimal},
+	{regexp.MustCompile(`bigint|int8`), ScalarBigInt},
+	// unsigned 32 bits integers overflow GraphQL Int
+	{regexp.MustCompile(`^(int|integer)\b.*unsigned`), ScalarBigInt},
+	{regexp.MustCompile(`int`), ScalarInt},
+	{regexp.MustCompile(`float|double|real`), ScalarFloat},
+	{regexp.MustCompile(`datetime|timestamp`), ScalarDateTime},
+	{regexp.MustCompile(`date`), ScalarDate},
+	{regexp.MustCompile(`time`), ScalarTime},
+	{regexp.MustCompile(`bit|bool`), ScalarBoolean},
+}
+
+// NewSQLTypeMapping returns a mapping of SQL types matching pattern to the scalar
+// named gqlType, ignoring case.
+func NewSQLTypeMapping(pattern string, gqlType string) (SQLTypeMapping, error) {
+	re, err := regexp.Compile("(?i)" + pattern)
+	if err != nil {
+		return SQLTypeMapping{}, err
+	}
+	scalar, err := ScalarType(gqlType)
+	if err != nil {
+		return SQLTypeMapping{}, err
+	}
+	return SQLTypeMapping{Pattern: re, Type: scalar}, nil
+}
+
+// ScalarType returns the scalar type named name.
+func ScalarType(name string) (GraphqlType, error) {
+	scalars := append([]GraphqlType{ScalarInt, ScalarFloat, ScalarString, ScalarBoolean, ScalarID}, customScalars...)
+	for _, scalar := range scalars {
+		if string(scalar) == name {
+			return scalar, nil
+		}
+	}
+	return "", fmt.Errorf("%s is not a scalar type", name)
+}
+
+// TypeMapper decides the Graphql type of table fields. Each SQLSchemaBuilder supplies
+// one for its dialect, which can be wrapped to override the type of some columns.
+type TypeMapper interface {
+	MapType(table *SQLTableStruct, field *SQLFieldStruct) GraphqlType
+}
+
+// TableTypeMapper maps fields by the first of Mappings matching their lower-cased SQL
+// type, asking Fallback when none does. Fields are String when there is no Fallback.
+type TableTypeMapper struct {
+	Mappings []SQLTypeMapping
+	Fallback TypeMapper
+}
+
+// MapType implementation
+func (mapper TableTypeMapper) MapType(table *SQLTableStruct, field *SQLFieldStruct) GraphqlType {
+	typeLower := strings.ToLower(field.Type)
+	for _, mapping := range mapper.Mappings {
+		if mapping.Pattern.MatchString(typeLower) {
+			return mapping.Type
+		}
+	}
+	if mapper.Fallback != nil {
+		return mapper.Fallback.MapType(table, field)
+	}
+	return ScalarString
+}
+
+// ColumnTypeMapper maps columns by name, either "table.column" or "column" for any
+// table, asking Fallback for the other columns. Columns are String when there is no
+// Fallback.
+type ColumnTypeMapper struct {
+	Columns  map[string]GraphqlType
+	Fallback TypeMapper
+}
+
+// MapType implementation
+func (mapper ColumnTypeMapper) MapType(table *SQLTableStruct, field *SQLFieldStruct) GraphqlType {
+	if gqlType, ok := mapper.Columns[fmt.Sprintf("%s.%s", table.Name, field.Field)]; ok {
+		return gqlType
+	}
+	if gqlType, ok := mapper.Columns[field.Field]; ok {
+		return gqlType
+	}
+	if mapper.Fallback != nil {
+		return mapper.Fallback.MapType(table, field)
+	}
+	return ScalarString
+}
+
+// DefaultTypeMapper returns a TypeMapper using TypeMappings.
+func DefaultTypeMapper() TypeMapper {
+	return TableTypeMapper{Mappings: TypeMappings}
+}